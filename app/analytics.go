@@ -0,0 +1,307 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"go.uber.org/zap"
+)
+
+type (
+	// AnalyticsService works out how balanced games are from the outcomes recorded for their playtests
+	AnalyticsService struct {
+		EventRepository    domain.EventRepository
+		GameRepository     domain.GameRepository
+		PlaytestRepository domain.PlaytestRepository
+		Logger             *zap.Logger
+	}
+
+	// Request DTOs
+
+	// GameAnalyticsRequest query params for a game's balance analytics
+	GameAnalyticsRequest struct {
+		From      string `form:"from" example:"2020-12-01"`
+		To        string `form:"to" example:"2020-12-31"`
+		EventID   uint   `form:"event_id" example:"123"`
+		VersionID uint   `form:"version_id" example:"123"`
+		Interval  string `form:"interval" binding:"omitempty,oneof=week month" example:"month"`
+	}
+
+	// Response DTOs
+
+	// GameAnalyticsResponse balance analytics for a game
+	GameAnalyticsResponse struct {
+		Analytics playtest.Analytics `json:"analytics"`
+	}
+)
+
+// confidenceZ is the critical value for a 95% confidence interval
+const confidenceZ = 1.96
+
+// GameAnalytics computes balance analytics across every playtest of the game with a recorded outcome.
+// Abandoned games only count towards the abandoned rate, since they have no meaningful winner.
+func (s *AnalyticsService) GameAnalytics(gameID uint, req *GameAnalyticsRequest) (*playtest.Analytics, error) {
+	g, err := s.GameRepository.GameOfID(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, domain.GameNotFound{ProvidedID: gameID}
+	}
+
+	e, err := eventOfID(s.EventRepository, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := dateRange(e, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	playtests, _, err := s.PlaytestRepository.PlaytestsOfGame(gameID, from, to, req.EventID, req.VersionID, -1, 0)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	analytics := analyzeOutcomes(playtests, req.Interval)
+
+	return &analytics, nil
+}
+
+// trendBucket accumulates the games played during a single period
+type trendBucket struct {
+	games       int
+	abandoned   int
+	completed   int
+	firstWins   int
+	margins     float64
+	marginGames int
+}
+
+// analyzeOutcomes does the actual number crunching for GameAnalytics
+func analyzeOutcomes(playtests []domain.Playtest, interval string) playtest.Analytics {
+	analytics := playtest.Analytics{
+		SeatWinRates: []playtest.SeatWinRate{},
+		PlayerCounts: []playtest.PlayerCountWinRate{},
+		Trend:        []playtest.TrendPoint{},
+	}
+
+	seats := map[uint]*playtest.SeatWinRate{}
+	counts := map[int]*playtest.PlayerCountWinRate{}
+	countSeats := map[int]map[uint]*playtest.SeatWinRate{}
+	trend := map[string]*trendBucket{}
+
+	scores := []int{}
+	var margins float64
+	marginGames := 0
+
+	firstGames, firstWins := 0, 0
+	var expected float64
+
+	for _, p := range playtests {
+		if len(p.Results) == 0 {
+			continue
+		}
+
+		period := trendPeriod(p.ScheduledDate, interval)
+		if trend[period] == nil {
+			trend[period] = &trendBucket{}
+		}
+		bucket := trend[period]
+		bucket.games++
+
+		if p.Ending == playtest.Abandoned {
+			bucket.abandoned++
+			continue
+		}
+
+		analytics.Games++
+		bucket.completed++
+
+		players := len(p.Results)
+		if counts[players] == nil {
+			counts[players] = &playtest.PlayerCountWinRate{Players: players}
+			countSeats[players] = map[uint]*playtest.SeatWinRate{}
+		}
+		counts[players].Games++
+
+		gameScores := []int{}
+		for _, r := range p.Results {
+			tallySeat(seats, r)
+			tallySeat(countSeats[players], r)
+
+			if r.Score != nil {
+				scores = append(scores, *r.Score)
+				gameScores = append(gameScores, *r.Score)
+			}
+
+			if r.Seat == 1 {
+				firstGames++
+				expected += 1 / float64(players)
+				if r.Winner {
+					firstWins++
+					bucket.firstWins++
+				}
+			}
+		}
+
+		if len(gameScores) > 1 {
+			sort.Ints(gameScores)
+			margin := float64(gameScores[len(gameScores)-1] - gameScores[0])
+			margins += margin
+			marginGames++
+			bucket.margins += margin
+			bucket.marginGames++
+		}
+	}
+
+	analytics.SeatWinRates = sortedSeats(seats)
+
+	for players, c := range counts {
+		c.SeatWinRates = sortedSeats(countSeats[players])
+		analytics.PlayerCounts = append(analytics.PlayerCounts, *c)
+	}
+	sort.Slice(analytics.PlayerCounts, func(i, j int) bool {
+		return analytics.PlayerCounts[i].Players < analytics.PlayerCounts[j].Players
+	})
+
+	analytics.ScoreSpread = scoreSpread(scores)
+	if marginGames > 0 {
+		analytics.ScoreSpread.AverageMargin = margins / float64(marginGames)
+	}
+
+	if firstGames > 0 {
+		rate := ratio(firstWins, firstGames)
+		lower, upper := wilsonInterval(firstWins, firstGames)
+		analytics.FirstPlayerAdvantage = playtest.FirstPlayerAdvantage{
+			Games:           firstGames,
+			WinRate:         rate,
+			ExpectedWinRate: expected / float64(firstGames),
+			Advantage:       rate - expected/float64(firstGames),
+			LowerBound:      lower,
+			UpperBound:      upper,
+		}
+	}
+
+	for period, bucket := range trend {
+		point := playtest.TrendPoint{
+			Period:             period,
+			Games:              bucket.games,
+			FirstPlayerWinRate: ratio(bucket.firstWins, bucket.completed),
+			AbandonedRate:      ratio(bucket.abandoned, bucket.games),
+		}
+
+		if bucket.marginGames > 0 {
+			point.AverageMargin = bucket.margins / float64(bucket.marginGames)
+		}
+
+		analytics.Trend = append(analytics.Trend, point)
+	}
+	sort.Slice(analytics.Trend, func(i, j int) bool {
+		return analytics.Trend[i].Period < analytics.Trend[j].Period
+	})
+
+	return analytics
+}
+
+// tallySeat counts a single result towards its seat's win rate
+func tallySeat(seats map[uint]*playtest.SeatWinRate, r domain.SeatResult) {
+	if seats[r.Seat] == nil {
+		seats[r.Seat] = &playtest.SeatWinRate{Seat: r.Seat}
+	}
+
+	seats[r.Seat].Games++
+	if r.Winner {
+		seats[r.Seat].Wins++
+	}
+
+	seats[r.Seat].WinRate = ratio(seats[r.Seat].Wins, seats[r.Seat].Games)
+}
+
+// sortedSeats flattens seat win rates in seat order
+func sortedSeats(seats map[uint]*playtest.SeatWinRate) []playtest.SeatWinRate {
+	sorted := []playtest.SeatWinRate{}
+	for _, s := range seats {
+		sorted = append(sorted, *s)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Seat < sorted[j].Seat
+	})
+
+	return sorted
+}
+
+// scoreSpread describes the distribution of every recorded score
+func scoreSpread(scores []int) playtest.ScoreSpread {
+	spread := playtest.ScoreSpread{Scores: len(scores)}
+	if len(scores) == 0 {
+		return spread
+	}
+
+	spread.Min, spread.Max = scores[0], scores[0]
+	var sum float64
+	for _, s := range scores {
+		if s < spread.Min {
+			spread.Min = s
+		}
+
+		if s > spread.Max {
+			spread.Max = s
+		}
+
+		sum += float64(s)
+	}
+
+	spread.Mean = sum / float64(len(scores))
+
+	var variance float64
+	for _, s := range scores {
+		variance += math.Pow(float64(s)-spread.Mean, 2)
+	}
+
+	spread.StdDev = math.Sqrt(variance / float64(len(scores)))
+
+	return spread
+}
+
+// wilsonInterval is the 95% Wilson score interval for a win rate. It behaves far better than the
+// normal approximation for the handful of games a prototype usually has.
+func wilsonInterval(wins, games int) (float64, float64) {
+	n := float64(games)
+	p := float64(wins) / n
+
+	z := confidenceZ
+	denominator := 1 + z*z/n
+	center := (p + z*z/(2*n)) / denominator
+	margin := z * math.Sqrt(p*(1-p)/n+z*z/(4*n*n)) / denominator
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// trendPeriod names the week or month a playtest falls in. Months are the default.
+func trendPeriod(date time.Time, interval string) string {
+	if interval == "week" {
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+
+	return date.Format("2006-01")
+}
+
+// ratio avoids dividing by zero when nothing has been counted
+func ratio(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(count) / float64(total)
+}
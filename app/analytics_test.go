@@ -0,0 +1,190 @@
+package app
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"go.uber.org/zap"
+)
+
+// fixturePlaytests stands in for the database, returning the same playtests for every game
+type fixturePlaytests struct {
+	playtests []domain.Playtest
+}
+
+func (r *fixturePlaytests) PlaytestsBetween(start, end time.Time, eventID uint, includeCancelled bool) ([]domain.Playtest, error) {
+	return r.playtests, nil
+}
+
+func (r *fixturePlaytests) PlaytestsOfGame(gameID uint, from, to time.Time, eventID, versionID uint, limit, offset int) ([]domain.Playtest, int, error) {
+	return r.playtests, len(r.playtests), nil
+}
+
+func (r *fixturePlaytests) HistoryOfGame(gameID uint, from, to time.Time, eventID, versionID uint) (playtest.HistoryMetrics, error) {
+	return playtest.HistoryMetrics{}, nil
+}
+
+func (r *fixturePlaytests) PlaytestOfID(id uint) (*domain.Playtest, error) {
+	return nil, nil
+}
+
+func (r *fixturePlaytests) AttendanceOfUsers(userIDs []uint) ([]domain.AttendanceRecord, error) {
+	return nil, nil
+}
+
+func (r *fixturePlaytests) Register(*domain.Playtest, *domain.GameVersion, uint, func(int) (*domain.CreditTransaction, error)) error {
+	return nil
+}
+
+func (r *fixturePlaytests) SaveAll([]domain.Playtest) error {
+	return nil
+}
+
+func (r *fixturePlaytests) SaveWithCredits(*domain.Playtest, []domain.CreditTransaction) error {
+	return nil
+}
+
+func (r *fixturePlaytests) Save(*domain.Playtest) error {
+	return nil
+}
+
+func (r *fixturePlaytests) EncryptSecrets() (int, error) {
+	return 0, nil
+}
+
+// fixtureGames stands in for the database, only knowing about a single game
+type fixtureGames struct {
+	domain.GameRepository
+	game *domain.Game
+}
+
+func (r *fixtureGames) GameOfID(id uint) (*domain.Game, error) {
+	if r.game == nil || r.game.ID != id {
+		return nil, nil
+	}
+
+	return r.game, nil
+}
+
+func result(seat uint, score int, winner bool) domain.SeatResult {
+	return domain.SeatResult{UserID: seat, Seat: seat, Score: &score, Winner: winner}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
+
+func TestGameAnalytics(t *testing.T) {
+	s := &AnalyticsService{
+		GameRepository: &fixtureGames{game: &domain.Game{ID: 1}},
+		PlaytestRepository: &fixturePlaytests{playtests: []domain.Playtest{
+			{
+				ScheduledDate: time.Date(2020, 12, 1, 18, 0, 0, 0, time.UTC),
+				Ending:        playtest.Completed,
+				Results:       []domain.SeatResult{result(1, 10, true), result(2, 6, false)},
+			},
+			{
+				ScheduledDate: time.Date(2020, 12, 15, 18, 0, 0, 0, time.UTC),
+				Ending:        playtest.Completed,
+				Results:       []domain.SeatResult{result(1, 4, false), result(2, 8, true)},
+			},
+			{
+				ScheduledDate: time.Date(2021, 1, 5, 18, 0, 0, 0, time.UTC),
+				Ending:        playtest.Completed,
+				Results:       []domain.SeatResult{result(1, 12, true), result(2, 9, false), result(3, 3, false)},
+			},
+			{
+				ScheduledDate: time.Date(2021, 1, 12, 18, 0, 0, 0, time.UTC),
+				Ending:        playtest.Abandoned,
+				Results:       []domain.SeatResult{result(1, 2, false), result(2, 1, false)},
+			},
+			{
+				// No outcome recorded, so it's ignored entirely
+				ScheduledDate: time.Date(2021, 1, 19, 18, 0, 0, 0, time.UTC),
+			},
+		}},
+		Logger: zap.NewNop(),
+	}
+
+	a, err := s.GameAnalytics(1, &GameAnalyticsRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error computing analytics: %s", err)
+	}
+
+	if a.Games != 3 {
+		t.Errorf("Expected 3 completed games, got %d", a.Games)
+	}
+
+	if len(a.SeatWinRates) != 3 {
+		t.Fatalf("Expected 3 seats, got %+v", a.SeatWinRates)
+	}
+
+	if a.SeatWinRates[0].Games != 3 || a.SeatWinRates[0].Wins != 2 || !near(a.SeatWinRates[0].WinRate, 2.0/3) {
+		t.Errorf("First seat incorrect: %+v", a.SeatWinRates[0])
+	}
+
+	if a.SeatWinRates[2].Seat != 3 || a.SeatWinRates[2].Games != 1 || a.SeatWinRates[2].Wins != 0 {
+		t.Errorf("Third seat incorrect: %+v", a.SeatWinRates[2])
+	}
+
+	if len(a.PlayerCounts) != 2 || a.PlayerCounts[0].Players != 2 || a.PlayerCounts[0].Games != 2 || a.PlayerCounts[1].Players != 3 || a.PlayerCounts[1].Games != 1 {
+		t.Errorf("Player counts incorrect: %+v", a.PlayerCounts)
+	}
+
+	if len(a.PlayerCounts[0].SeatWinRates) != 2 || a.PlayerCounts[0].SeatWinRates[0].WinRate != 0.5 {
+		t.Errorf("Two player seat win rates incorrect: %+v", a.PlayerCounts[0].SeatWinRates)
+	}
+
+	spread := a.ScoreSpread
+	if spread.Scores != 7 || spread.Min != 3 || spread.Max != 12 || !near(spread.Mean, 52.0/7) || !near(spread.AverageMargin, 17.0/3) {
+		t.Errorf("Score spread incorrect: %+v", spread)
+	}
+
+	first := a.FirstPlayerAdvantage
+	if first.Games != 3 || !near(first.WinRate, 2.0/3) || !near(first.ExpectedWinRate, 4.0/9) || !near(first.Advantage, 2.0/9) {
+		t.Errorf("First player advantage incorrect: %+v", first)
+	}
+
+	if !near(first.LowerBound, 0.2077) || !near(first.UpperBound, 0.9385) {
+		t.Errorf("Confidence interval incorrect: %f - %f", first.LowerBound, first.UpperBound)
+	}
+
+	if len(a.Trend) != 2 {
+		t.Fatalf("Expected 2 months, got %+v", a.Trend)
+	}
+
+	if a.Trend[0].Period != "2020-12" || a.Trend[0].Games != 2 || a.Trend[0].FirstPlayerWinRate != 0.5 || a.Trend[0].AverageMargin != 4 || a.Trend[0].AbandonedRate != 0 {
+		t.Errorf("December incorrect: %+v", a.Trend[0])
+	}
+
+	if a.Trend[1].Period != "2021-01" || a.Trend[1].Games != 2 || a.Trend[1].FirstPlayerWinRate != 1 || a.Trend[1].AverageMargin != 9 || a.Trend[1].AbandonedRate != 0.5 {
+		t.Errorf("January incorrect: %+v", a.Trend[1])
+	}
+
+	a, err = s.GameAnalytics(1, &GameAnalyticsRequest{Interval: "week"})
+	if err != nil {
+		t.Fatalf("Unexpected error computing analytics: %s", err)
+	}
+
+	if len(a.Trend) != 4 || a.Trend[0].Period != "2020-W49" || a.Trend[3].Period != "2021-W02" || a.Trend[3].AbandonedRate != 1 {
+		t.Errorf("Weekly trend incorrect: %+v", a.Trend)
+	}
+
+	if _, err := s.GameAnalytics(1, &GameAnalyticsRequest{From: "yesterday"}); err == nil {
+		t.Error("Invalid dates should be rejected")
+	}
+
+	if _, err := s.GameAnalytics(2, &GameAnalyticsRequest{}); err != (domain.GameNotFound{ProvidedID: 2}) {
+		t.Errorf("Expected unknown games to be rejected, got %v", err)
+	}
+}
+
+func TestGameAnalyticsWithoutOutcomes(t *testing.T) {
+	a := analyzeOutcomes([]domain.Playtest{}, "")
+	if a.Games != 0 || len(a.SeatWinRates) != 0 || a.FirstPlayerAdvantage.Games != 0 || len(a.Trend) != 0 {
+		t.Errorf("Expected empty analytics, got %+v", a)
+	}
+}
@@ -9,6 +9,7 @@ type (
 	// AuthService handles both authentication and authorization
 	AuthService struct {
 		AuthToken              string
+		CreditRepository       domain.CreditRepository
 		Logger                 *zap.Logger
 		LoginAttemptRepository domain.LoginAttemptRepository
 		UserRepository         domain.UserRepository
@@ -44,12 +45,27 @@ type (
 		Email string `json:"email" binding:"required,email" example:"user@example.com"`
 	}
 
+	// CreditHistoryRequest query params for the user's credit transactions
+	CreditHistoryRequest struct {
+		Limit  int `form:"limit" example:"100"`
+		Offset int `form:"offset" example:"50"`
+	}
+
 	// Response DTOs
 
 	// UserResponse wraps User object
 	UserResponse struct {
 		User *domain.User `json:"user"`
 	}
+
+	// CreditHistoryResponse the user's credit balance and paginated transaction history
+	CreditHistoryResponse struct {
+		Balance      int                        `json:"balance" example:"3"`
+		Transactions []domain.CreditTransaction `json:"transactions"`
+		Total        int                        `json:"total" example:"1000"`
+		Limit        int                        `json:"limit" example:"100"`
+		Offset       int                        `json:"offset" example:"50"`
+	}
 )
 
 // UpdateUser will update the user with the specified values
@@ -242,8 +258,45 @@ func (s *AuthService) FetchUser(id uint) (*domain.User, error) {
 		return nil, err
 	}
 
-	// Decorate the email for this response
+	if user == nil {
+		return nil, nil
+	}
+
+	balance, err := s.CreditRepository.BalanceOf(user.ID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Decorate the email, credits & admin flag for this response
 	user.Email = user.Account.Email
+	user.Credits = &balance
+	user.IsAdmin = user.Admin
 
 	return user, nil
 }
+
+// CreditHistory returns the user's credit balance along with a page of their transactions, most recent first
+func (s *AuthService) CreditHistory(req *CreditHistoryRequest, userID uint) (int, []domain.CreditTransaction, int, error) {
+	// Limit our limit
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	balance, err := s.CreditRepository.BalanceOf(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return 0, nil, 0, err
+	}
+
+	transactions, total, err := s.CreditRepository.TransactionsOf(userID, req.Limit, req.Offset)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return 0, nil, 0, err
+	}
+
+	return balance, transactions, total, nil
+}
@@ -0,0 +1,276 @@
+package app
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
+	"go.uber.org/zap"
+)
+
+type (
+	// DesignerService handles who designs each game, through invitations rather than direct edits
+	DesignerService struct {
+		DesignerRepository domain.DesignerRepository
+		GameRepository     domain.GameRepository
+		UserRepository     domain.UserRepository
+		Logger             *zap.Logger
+	}
+
+	// Request DTOs
+
+	// InviteDesignerRequest params for inviting someone to co-design a game, either an existing user or
+	// someone by email
+	InviteDesignerRequest struct {
+		UserID uint   `json:"user_id" binding:"required_without=Email" example:"123"`
+		Email  string `json:"email" binding:"required_without=UserID,omitempty,email" example:"friend@example.com"`
+	}
+
+	// TransferOwnershipRequest params for handing a game to another of its designers
+	TransferOwnershipRequest struct {
+		UserID uint `json:"user_id" binding:"required" example:"123"`
+	}
+
+	// Response DTOs
+
+	// ListInvitationsResponse wrapper for a listing of invitations
+	ListInvitationsResponse struct {
+		Invitations []domain.DesignerInvitation `json:"invitations"`
+	}
+
+	// InvitationResponse wrapper around a single invitation
+	InvitationResponse struct {
+		Invitation *domain.DesignerInvitation `json:"invitation"`
+	}
+
+	// DesignerHistoryResponse wrapper around every change to a game's designers
+	DesignerHistoryResponse struct {
+		Changes []domain.DesignerChange `json:"changes"`
+	}
+)
+
+// ListInvitations returns every invitation to co-design a game, newest first. Only designers may see them.
+func (s *DesignerService) ListInvitations(gameID, userID uint) ([]domain.DesignerInvitation, error) {
+	if _, _, err := s.editableGame(gameID, userID, "see the invitations for this game"); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.DesignerRepository.InvitationsOfGame(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// InviteDesigner asks a user, or someone by email, to co-design a game. Emails belonging to existing users
+// invite that user. Only designers may invite others.
+func (s *DesignerService) InviteDesigner(gameID uint, req *InviteDesignerRequest, userID uint) (*domain.DesignerInvitation, error) {
+	g, user, err := s.editableGame(gameID, userID, "invite designers to this game")
+	if err != nil {
+		return nil, err
+	}
+
+	var invitee *domain.User
+	if req.UserID != 0 {
+		invitee, err = s.UserRepository.UserOfID(req.UserID)
+	} else {
+		invitee, err = s.UserRepository.UserOfEmail(req.Email)
+	}
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if req.UserID != 0 && invitee == nil {
+		return nil, domain.UserNotFound{ProvidedID: req.UserID}
+	}
+
+	invitations, err := s.DesignerRepository.InvitationsOfGame(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	invitation, change, err := g.InviteDesigner(invitee, req.Email, user, invitations)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.DesignerRepository.SaveInvitation(invitation, change)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Let the invitee know
+	event := domain.DesignerInvited(invitation)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	return invitation, nil
+}
+
+// MyInvitations returns the invitations waiting on the current user's answer, oldest first
+func (s *DesignerService) MyInvitations(userID uint) ([]domain.DesignerInvitation, error) {
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.UserNotFound{ProvidedID: userID}
+	}
+
+	invitations, err := s.DesignerRepository.InvitationsForUser(user)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation makes the current user a designer of the game they were invited to
+func (s *DesignerService) AcceptInvitation(invitationID, userID uint) (*domain.DesignerInvitation, error) {
+	return s.updateInvitation(invitationID, userID, (*domain.DesignerInvitation).Accept)
+}
+
+// DeclineInvitation turns down an invitation to co-design a game
+func (s *DesignerService) DeclineInvitation(invitationID, userID uint) (*domain.DesignerInvitation, error) {
+	return s.updateInvitation(invitationID, userID, (*domain.DesignerInvitation).Decline)
+}
+
+// CancelInvitation withdraws an invitation that hasn't been answered yet. Only designers may do so.
+func (s *DesignerService) CancelInvitation(invitationID, userID uint) (*domain.DesignerInvitation, error) {
+	return s.updateInvitation(invitationID, userID, (*domain.DesignerInvitation).Cancel)
+}
+
+// RemoveDesigner takes a designer off a game. Designers may leave on their own, while only the owner may
+// remove others.
+func (s *DesignerService) RemoveDesigner(gameID, designerID, userID uint) (*domain.Game, error) {
+	g, user, err := s.editableGame(gameID, userID, "remove designers from this game")
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := g.RemoveDesigner(designerID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.DesignerRepository.SaveDesigners(g, change)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// TransferOwnership hands a game to another of its designers. Only the owner may do so.
+func (s *DesignerService) TransferOwnership(gameID uint, req *TransferOwnershipRequest, userID uint) (*domain.Game, error) {
+	g, user, err := s.editableGame(gameID, userID, "transfer ownership of this game")
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := g.TransferOwnership(req.UserID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.DesignerRepository.SaveDesigners(g, change)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// DesignerHistory returns every change to who designs a game, oldest first. Only designers may see it.
+func (s *DesignerService) DesignerHistory(gameID, userID uint) ([]domain.DesignerChange, error) {
+	if _, _, err := s.editableGame(gameID, userID, "see the designer history of this game"); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.DesignerRepository.DesignerHistory(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// updateInvitation applies an answer or cancellation to an invitation, then saves it along with the change
+func (s *DesignerService) updateInvitation(invitationID, userID uint, update func(*domain.DesignerInvitation, *domain.User) (*domain.DesignerChange, error)) (*domain.DesignerInvitation, error) {
+	invitation, err := s.DesignerRepository.InvitationOfID(invitationID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if invitation == nil {
+		return nil, domain.InvitationNotFound{ProvidedID: invitationID}
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.UserNotFound{ProvidedID: userID}
+	}
+
+	change, err := update(invitation, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.DesignerRepository.SaveInvitation(invitation, change)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Answers are passed on to the game's other designers
+	if invitation.Status == game.InvitationAccepted || invitation.Status == game.InvitationDeclined {
+		event := domain.InvitationAnswered(invitation)
+		pubsub.Instance.Publish(event.Name, event.Data)
+	}
+
+	return invitation, nil
+}
+
+// editableGame fetches a game, provided the user is one of its designers
+func (s *DesignerService) editableGame(gameID, userID uint, action string) (*domain.Game, *domain.User, error) {
+	g, err := s.GameRepository.GameOfID(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if g == nil {
+		return nil, nil, domain.GameNotFound{ProvidedID: gameID}
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if !g.MayBeUpdatedBy(user) {
+		return nil, nil, domain.Forbidden{Action: action}
+	}
+
+	return g, user, nil
+}
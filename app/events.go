@@ -2,6 +2,7 @@ package app
 
 import (
 	"errors"
+	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/event"
@@ -20,13 +21,16 @@ type (
 
 	// CreateEventRequest params for creating an event
 	CreateEventRequest struct {
-		Title    string `json:"title" binding:"required"`
-		Details  string `json:"details" binding:"required"`
-		Type     string `json:"type" binding:"required"`
-		URL      string `json:"url" binding:"omitempty,url"`
-		Location string `json:"location"`
-		Duration int64  `json:"duration"`
-		RRule    string `json:"rrule" binding:"required"`
+		Title      string `json:"title" binding:"required"`
+		Details    string `json:"details" binding:"required"`
+		Type       string `json:"type" binding:"required"`
+		URL        string `json:"url" binding:"omitempty,url"`
+		Location   string `json:"location"`
+		Duration   int64  `json:"duration"`
+		SlotLength int64  `json:"slot_length"`
+		RRule      string `json:"rrule" binding:"required"`
+
+		RegistrationCost uint `json:"registration_cost" example:"2"`
 	}
 
 	// UpdateEventRequest params for updating an event
@@ -38,7 +42,15 @@ type (
 		URL          string `json:"url" binding:"omitempty,url"`
 		Location     string `json:"location"`
 		Duration     int64  `json:"duration"`
+		SlotLength   int64  `json:"slot_length"`
 		RRule        string `json:"rrule"`
+
+		RegistrationCost *uint `json:"registration_cost" example:"2"`
+	}
+
+	// EventSlotsRequest query params for an event's slots
+	EventSlotsRequest struct {
+		Date string `form:"date" binding:"required" example:"2020-12-16"`
 	}
 
 	// Response DTOs
@@ -52,6 +64,11 @@ type (
 	EventResponse struct {
 		Event *domain.Event `json:"event"`
 	}
+
+	// EventSlotsResponse slots available on a date
+	EventSlotsResponse struct {
+		Slots []event.Slot `json:"slots"`
+	}
 )
 
 // ListEvents returns all events matching the specified query. The results are paginated
@@ -84,6 +101,12 @@ func (s *EventService) CreateEvent(req *CreateEventRequest, userID uint) (*domai
 		e = domain.NewInPersonEvent(req.Title, req.Details, req.Location, req.Duration, req.RRule, *user)
 	}
 
+	if req.SlotLength != 0 {
+		e.UpdateSlotLength(req.SlotLength)
+	}
+
+	e.UpdateRegistrationCost(req.RegistrationCost)
+
 	// And save
 	err = s.EventRepository.Save(e)
 	if err != nil {
@@ -105,6 +128,32 @@ func (s *EventService) GetEvent(eventID uint) (*domain.Event, error) {
 	return e, nil
 }
 
+// EventSlots returns the slots of every occurrence of an event on a date
+func (s *EventService) EventSlots(eventID uint, req *EventSlotsRequest) ([]event.Slot, error) {
+	e, err := s.EventRepository.EventOfID(eventID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if e == nil {
+		return nil, domain.EventNotFound{ProvidedID: eventID}
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := e.SlotsOn(date)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return slots, nil
+}
+
 // UpdateEvent updates a specific event
 func (s *EventService) UpdateEvent(eventID uint, req *UpdateEventRequest, userID uint) (*domain.Event, error) {
 	e, err := s.EventRepository.EventOfID(eventID)
@@ -172,10 +221,19 @@ func (s *EventService) UpdateEvent(eventID uint, req *UpdateEventRequest, userID
 		e.UpdateDuration(req.Duration)
 	}
 
+	if req.SlotLength != 0 {
+		e.UpdateSlotLength(req.SlotLength)
+	}
+
 	if req.RRule != "" {
 		e.UpdateRRule(req.RRule)
 	}
 
+	// Zero makes the event free again, so only skip it when it's left out entirely
+	if req.RegistrationCost != nil {
+		e.UpdateRegistrationCost(*req.RegistrationCost)
+	}
+
 	// And save
 	err = s.EventRepository.Save(e)
 	if err != nil {
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/feedback"
+	"go.uber.org/zap"
+)
+
+type (
+	// FeedbackService handles collecting and reporting feedback on playtests
+	FeedbackService struct {
+		FeedbackRepository domain.FeedbackRepository
+		PlaytestRepository domain.PlaytestRepository
+		UserRepository     domain.UserRepository
+		Logger             *zap.Logger
+	}
+
+	// Request DTOs
+
+	// Ratings wrapper for structured feedback scores
+	Ratings struct {
+		Fun           uint `json:"fun" binding:"required,min=1,max=5" example:"4"`
+		Clarity       uint `json:"clarity" binding:"required,min=1,max=5" example:"3"`
+		Pacing        uint `json:"pacing" binding:"required,min=1,max=5" example:"4"`
+		Replayability uint `json:"replayability" binding:"required,min=1,max=5" example:"5"`
+		Overall       uint `json:"overall" binding:"required,min=1,max=5" example:"4"`
+	}
+
+	// LeaveFeedbackRequest params for a player's response to a playtest
+	LeaveFeedbackRequest struct {
+		Ratings            Ratings `json:"ratings" binding:"required"`
+		Comments           string  `json:"comments" example:"Loved the theme! The end game dragged a bit."`
+		HopingToTestAnswer string  `json:"hoping_to_test_answer" example:"Yes, after the first round"`
+	}
+
+	// Response DTOs
+
+	// FeedbackResponse wrapper around a single player's feedback
+	FeedbackResponse struct {
+		Feedback *domain.Feedback `json:"feedback"`
+	}
+
+	// PlaytestFeedbackResponse aggregate and individual feedback for a playtest
+	PlaytestFeedbackResponse struct {
+		Summary   feedback.Summary  `json:"summary"`
+		Responses []domain.Feedback `json:"responses"`
+	}
+)
+
+// LeaveFeedback records (or revises) the current user's feedback on a playtest
+func (s *FeedbackService) LeaveFeedback(playtestID uint, req *LeaveFeedbackRequest, userID uint) (*domain.Feedback, error) {
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.UserNotFound{ProvidedID: userID}
+	}
+
+	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if playtest == nil {
+		return nil, domain.PlaytestNotFound{ProvidedID: playtestID}
+	}
+
+	ratings := feedback.Ratings{
+		Fun:           req.Ratings.Fun,
+		Clarity:       req.Ratings.Clarity,
+		Pacing:        req.Ratings.Pacing,
+		Replayability: req.Ratings.Replayability,
+		Overall:       req.Ratings.Overall,
+	}
+
+	// Players may change their minds, so we update any existing response rather than adding another
+	response, err := s.FeedbackRepository.FeedbackOfPlayer(playtest.ID, user.ID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if response == nil {
+		response, err = domain.LeaveFeedback(playtest, user, ratings, req.Comments, req.HopingToTestAnswer)
+	} else {
+		err = response.Revise(playtest, ratings, req.Comments, req.HopingToTestAnswer)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.FeedbackRepository.Save(response)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// PlaytestFeedback returns the aggregate and individual feedback for a playtest. Only designers may read it.
+func (s *FeedbackService) PlaytestFeedback(playtestID uint, userID uint) (*feedback.Summary, []domain.Feedback, error) {
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if playtest == nil {
+		return nil, nil, domain.PlaytestNotFound{ProvidedID: playtestID}
+	}
+
+	if !playtest.Game.MayBeUpdatedBy(user) {
+		return nil, nil, domain.Forbidden{Action: "read feedback for this playtest"}
+	}
+
+	responses, err := s.FeedbackRepository.FeedbackOfPlaytest(playtest.ID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	summary := domain.SummarizeFeedback(playtest, responses)
+
+	return &summary, responses, nil
+}
@@ -2,24 +2,33 @@ package app
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/rulebook"
 	"go.uber.org/zap"
 )
 
 type (
 	// GameService handles general interactions with games
 	GameService struct {
-		GameRepository domain.GameRepository
-		UserRepository domain.UserRepository
-		Logger         *zap.Logger
+		GameRepository       domain.GameRepository
+		MechanicRepository   domain.MechanicRepository
+		PublisherRepository  domain.PublisherRepository
+		SubmissionRepository domain.SubmissionRepository
+		UserRepository       domain.UserRepository
+		Logger               *zap.Logger
 	}
 
 	// Request DTOs
 
 	// ListGamesRequest query params
 	ListGamesRequest struct {
+		Search      string `form:"q" example:"trick-taking pirates"`
 		Title       string `form:"title" example:"New Game"`
 		Status      string `form:"status" example:"Prototype"`
 		Designer    string `form:"designer" example:"Designer McDesignerton"`
@@ -42,36 +51,98 @@ type (
 
 	// CreateGameRequest params for creating a game
 	CreateGameRequest struct {
-		Title     string `json:"title" binding:"required"`
-		Overview  string `json:"overview"`
-		Designers []uint `json:"designers"`
-		Stats     *Stats `json:"stats" binding:"omitempty,dive"`
+		Title    string `json:"title" binding:"required"`
+		Overview string `json:"overview"`
+		Stats    *Stats `json:"stats" binding:"omitempty,dive"`
 	}
 
 	// UpdateGameRequest params for updating a game
 	UpdateGameRequest struct {
 		Title     string   `json:"title"`
 		Overview  string   `json:"overview"`
-		Status    string   `json:"status"`
-		Designers []uint   `json:"designers"`
 		Stats     *Stats   `json:"stats" binding:"omitempty,dive"`
 		Mechanics []string `json:"mechanics" example:"['Hidden Movement', 'Worker Placement']"`
 		TTSMod    int      `json:"tts_mod" example:"12345678"`
+
+		// Status is rejected here, since it has a workflow of its own. See ChangeStatusRequest.
+		Status string `json:"status" swaggerignore:"true"`
+	}
+
+	// CreateRulesSectionRequest params for adding a section to the end of a game's rules
+	CreateRulesSectionRequest struct {
+		Title   string `json:"title" binding:"required" example:"Components"`
+		Content string `json:"content" example:"<ul><li>52 Cards</li><li>10 dice</li>..."`
+	}
+
+	// UpdateRulesSectionRequest params for updating a rules section. Blank values are left unchanged.
+	UpdateRulesSectionRequest struct {
+		Title   string `json:"title" example:"Components"`
+		Content string `json:"content" example:"<ul><li>52 Cards</li><li>10 dice</li>..."`
+	}
+
+	// ReorderRulesRequest lists every section of a game's rules, by ID, in their new order
+	ReorderRulesRequest struct {
+		Sections []uint `json:"sections" binding:"required" example:"3,1,2"`
+	}
+
+	// ListRevisionsRequest query params for a game's rules history
+	ListRevisionsRequest struct {
+		SectionID uint `form:"section" example:"123"`
+		Limit     int  `form:"limit" example:"100"`
+		Offset    int  `form:"offset" example:"50"`
+	}
+
+	// RulesDiffRequest query params for comparing a game's rules at two points in time. Each point is either
+	// a revision or the end of a date. Leave the later point out to compare against the current rules.
+	RulesDiffRequest struct {
+		From     uint   `form:"from" binding:"required_without=FromDate" example:"120"`
+		FromDate string `form:"from_date" binding:"required_without=From" example:"2020-12-01"`
+		To       uint   `form:"to" example:"123"`
+		ToDate   string `form:"to_date" example:"2020-12-31"`
+	}
+
+	// ExportRulesRequest query params for downloading a game's rules as a single document
+	ExportRulesRequest struct {
+		Format string `form:"format" binding:"required,oneof=md html pdf" example:"pdf"`
+	}
+
+	// ImportRulesRequest form params for importing rules from a Markdown document. Merging updates sections
+	// with matching titles and adds the rest, while replacing removes every existing section first.
+	ImportRulesRequest struct {
+		Mode   string `form:"mode" binding:"omitempty,oneof=merge replace" example:"merge"`
+		DryRun bool   `form:"dry_run" example:"true"`
+	}
+
+	// ChangeStatusRequest params for moving a game along its lifecycle. Signing a game requires a publisher who
+	// accepted one of its submissions and the date the contract was signed.
+	ChangeStatusRequest struct {
+		Status       string `json:"status" binding:"required,oneof=Prototype Signed Published Archived" example:"Signed"`
+		Note         string `json:"note" example:"Signed at Gen Con!"`
+		PublisherID  uint   `json:"publisher_id" example:"123"`
+		ContractDate string `json:"contract_date" binding:"omitempty,datetime=2006-01-02" example:"2021-03-01"`
+	}
+
+	// CreateVersionRequest params for snapshotting the current state of a game
+	CreateVersionRequest struct {
+		Name  string `json:"name" binding:"required" example:"v2 - Simplified scoring"`
+		Notes string `json:"notes" example:"Scoring now happens at the end of each round"`
 	}
 
 	// Response DTOs
 
 	// ListGamesResponse paginated games list
 	ListGamesResponse struct {
-		Games  []domain.Game `json:"games"`
-		Total  int           `json:"total" example:"1000"`
-		Limit  int           `json:"limit" example:"100"`
-		Offset int           `json:"offset" example:"50"`
+		Games      []domain.Game    `json:"games"`
+		Highlights []game.Highlight `json:"highlights,omitempty"`
+		Total      int              `json:"total" example:"1000"`
+		Limit      int              `json:"limit" example:"100"`
+		Offset     int              `json:"offset" example:"50"`
 	}
 
 	// GameResponse wrapper around a game
 	GameResponse struct {
-		Game *domain.Game `json:"game"`
+		Game     *domain.Game     `json:"game"`
+		Rejected []game.Rejection `json:"rejected,omitempty"`
 	}
 
 	// RulesResponse wrapper around a collection of rules sections
@@ -79,14 +150,56 @@ type (
 		Rules []game.RulesSection `json:"rules"`
 	}
 
-	// ListMechanicsResponse wrapper for a listing of mechanics
-	ListMechanicsResponse struct {
-		Mechanics []string `json:"mechanics" example:"['trick-taking', 'worker placement', ...]"`
+	// RulesSectionResponse wrapper around a single rules section
+	RulesSectionResponse struct {
+		Section  *game.RulesSection `json:"section"`
+		Rejected []game.Rejection   `json:"rejected,omitempty"`
+	}
+
+	// ListRevisionsResponse paginated rules history
+	ListRevisionsResponse struct {
+		Revisions []domain.RulesRevision `json:"revisions"`
+		Total     int                    `json:"total" example:"1000"`
+		Limit     int                    `json:"limit" example:"100"`
+		Offset    int                    `json:"offset" example:"50"`
+	}
+
+	// ImportRulesResponse what an import did to a game's rules, or would do for a dry run
+	ImportRulesResponse struct {
+		DryRun bool                `json:"dry_run" example:"true"`
+		Import *domain.RulesImport `json:"import"`
+	}
+
+	// RulesDiffResponse section by section comparison of a game's rules
+	RulesDiffResponse struct {
+		Sections []game.SectionDiff `json:"sections"`
+	}
+
+	// StatusChangeResponse wrapper around the game after a status change, and the change itself
+	StatusChangeResponse struct {
+		Game   *domain.Game         `json:"game"`
+		Change *domain.StatusChange `json:"change"`
+	}
+
+	// StatusHistoryResponse wrapper around every status change of a game
+	StatusHistoryResponse struct {
+		Changes []domain.StatusChange `json:"changes"`
+	}
+
+	// ListVersionsResponse wrapper around a game's versions
+	ListVersionsResponse struct {
+		Versions []domain.GameVersion `json:"versions"`
+	}
+
+	// VersionResponse wrapper around a single version of a game
+	VersionResponse struct {
+		Version *domain.GameVersion `json:"version"`
 	}
 )
 
-// ListGames returns all games matching the specified query. The results are paginated
-func (s *GameService) ListGames(req *ListGamesRequest) ([]domain.Game, int, error) {
+// ListGames returns all games matching the specified query. The results are paginated. Full-text searches are
+// ranked by relevance and come with highlights showing where each game matched.
+func (s *GameService) ListGames(req *ListGamesRequest) ([]domain.Game, []game.Highlight, int, error) {
 	// Limit our limit
 	if req.Limit == 0 {
 		req.Limit = 10
@@ -97,6 +210,7 @@ func (s *GameService) ListGames(req *ListGamesRequest) ([]domain.Game, int, erro
 
 	// Fetch games
 	games, total, err := s.GameRepository.ListGames(
+		strings.TrimSpace(req.Search),
 		req.Title,
 		req.Status,
 		req.Designer,
@@ -111,42 +225,39 @@ func (s *GameService) ListGames(req *ListGamesRequest) ([]domain.Game, int, erro
 
 	if err != nil {
 		s.Logger.Error(err.Error())
-		return nil, 0, err
+		return nil, nil, 0, err
+	}
+
+	if strings.TrimSpace(req.Search) == "" {
+		return games, nil, total, nil
+	}
+
+	ids := []uint{}
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+
+	highlights, err := s.GameRepository.HighlightGames(ids, strings.TrimSpace(req.Search))
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, 0, err
 	}
 
-	return games, total, nil
+	return games, highlights, total, nil
 }
 
-// CreateGame creates a new stub game
-func (s *GameService) CreateGame(req *CreateGameRequest, userID uint) (*domain.Game, error) {
+// CreateGame creates a new stub game. Any markup stripped from the overview is returned as well.
+func (s *GameService) CreateGame(req *CreateGameRequest, userID uint) (*domain.Game, []game.Rejection, error) {
 	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	game := domain.NewGame(req.Title, *user)
 
 	// If the request included optional information, add it now
-	if req.Overview != "" {
-		game.UpdateOverview(req.Overview)
-	}
-
-	if len(req.Designers) > 1 { // Index 0 is always the current user, which is included already
-		for _, designerID := range req.Designers {
-			if designerID == user.ID {
-				continue
-			}
-
-			designer, err := s.UserRepository.UserOfID(designerID)
-			if err != nil {
-				s.Logger.Error(err.Error())
-				return nil, err
-			}
-
-			game.AddDesigner(designer)
-		}
-	}
+	rejected := game.UpdateOverview(req.Overview)
 
 	if req.Stats != nil {
 		game.UpdateStats(req.Stats.MinPlayers, req.Stats.MaxPlayers, req.Stats.MinAge, req.Stats.EstimatedPlaytime)
@@ -156,10 +267,10 @@ func (s *GameService) CreateGame(req *CreateGameRequest, userID uint) (*domain.G
 	err = s.GameRepository.Save(game)
 	if err != nil {
 		s.Logger.Error(err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	return game, nil
+	return game, rejected, nil
 }
 
 // GetGame returns a specific game
@@ -184,27 +295,27 @@ func (s *GameService) GetRules(gameID uint) ([]game.RulesSection, error) {
 	return rules, nil
 }
 
-// UpdateGame updates a specific game
-func (s *GameService) UpdateGame(gameID uint, req *UpdateGameRequest, userID uint) (*domain.Game, error) {
+// UpdateGame updates a specific game. Any markup stripped from the overview is returned as well.
+func (s *GameService) UpdateGame(gameID uint, req *UpdateGameRequest, userID uint) (*domain.Game, []game.Rejection, error) {
 	game, err := s.GameRepository.GameOfID(gameID)
 	if err != nil {
 		s.Logger.Error(err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	if game == nil {
-		return nil, errors.New("game not found")
+		return nil, nil, errors.New("game not found")
 	}
 
 	// Ensure that our current user is allowed to edit the game
 	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	if !game.MayBeUpdatedBy(user) {
-		return nil, errors.New("you may not edit this game")
+		return nil, nil, errors.New("you may not edit this game")
 	}
 
 	// Update game
@@ -212,55 +323,492 @@ func (s *GameService) UpdateGame(gameID uint, req *UpdateGameRequest, userID uin
 		game.Rename(req.Title)
 	}
 
-	if req.Overview != "" {
-		game.UpdateOverview(req.Overview)
+	rejected := game.UpdateOverview(req.Overview)
+
+	if req.Stats != nil {
+		game.UpdateStats(req.Stats.MinPlayers, req.Stats.MaxPlayers, req.Stats.MinAge, req.Stats.EstimatedPlaytime)
 	}
 
-	if req.Status != "" {
-		err := game.UpdateStatus(req.Status)
+	if req.Mechanics != nil {
+		taxonomy, err := s.MechanicRepository.AllMechanics()
 		if err != nil {
 			s.Logger.Error(err.Error())
+			return nil, nil, err
+		}
+
+		mechanics, err := domain.ResolveMechanics(taxonomy, req.Mechanics)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		game.ReplaceMechanics(mechanics)
+	}
+
+	if req.TTSMod != 0 {
+		game.LinkTabletopSimulatorMod(req.TTSMod)
+	}
+
+	// And save
+	err = s.GameRepository.Save(game)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	return game, rejected, nil
+}
+
+// CreateRulesSection adds a new section to the end of a game's rules. Any markup stripped from its content
+// is returned as well.
+func (s *GameService) CreateRulesSection(gameID uint, req *CreateRulesSectionRequest, userID uint) (*game.RulesSection, []game.Rejection, error) {
+	g, user, err := s.editableGame(gameID, userID, "edit the rules of this game")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	section, rejected := g.AddRulesSection(req.Title, req.Content)
+
+	// And save
+	err = s.GameRepository.SaveRulesSection(section, domain.ReviseRulesSection(section, user))
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	return section, rejected, nil
+}
+
+// UpdateRulesSection changes the title and/or content of a section of a game's rules. Any markup stripped
+// from its content is returned as well.
+func (s *GameService) UpdateRulesSection(gameID, sectionID uint, req *UpdateRulesSectionRequest, userID uint) (*game.RulesSection, []game.Rejection, error) {
+	g, user, err := s.editableGame(gameID, userID, "edit the rules of this game")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	section, err := g.RulesSection(sectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.Title != "" {
+		section.UpdateTitle(req.Title)
+	}
+
+	rejected := []game.Rejection{}
+	if req.Content != "" {
+		rejected = section.UpdateContent(req.Content)
+	}
+
+	// And save
+	err = s.GameRepository.SaveRulesSection(section, domain.ReviseRulesSection(section, user))
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	return section, rejected, nil
+}
+
+// DeleteRulesSection removes a section from a game's rules
+func (s *GameService) DeleteRulesSection(gameID, sectionID uint, userID uint) error {
+	g, user, err := s.editableGame(gameID, userID, "edit the rules of this game")
+	if err != nil {
+		return err
+	}
+
+	section, err := g.RemoveRulesSection(sectionID)
+	if err != nil {
+		return err
+	}
+
+	err = s.GameRepository.DeleteRulesSection(section, domain.RemoveRulesSectionRevision(section, user))
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ReorderRules rearranges every section of a game's rules at once
+func (s *GameService) ReorderRules(gameID uint, req *ReorderRulesRequest, userID uint) ([]game.RulesSection, error) {
+	g, user, err := s.editableGame(gameID, userID, "edit the rules of this game")
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[uint]uint{}
+	for _, section := range g.Rules {
+		previous[section.ID] = section.OrderBy
+	}
+
+	if err := g.ReorderRules(req.Sections); err != nil {
+		return nil, err
+	}
+
+	// Only sections that actually moved have changed
+	revisions := []domain.RulesRevision{}
+	for i := range g.Rules {
+		if previous[g.Rules[i].ID] != g.Rules[i].OrderBy {
+			revisions = append(revisions, *domain.ReviseRulesSection(&g.Rules[i], user))
+		}
+	}
+
+	// And save
+	err = s.GameRepository.ReorderRules(g.Rules, revisions)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return g.Rules, nil
+}
+
+// ImportRules splits a Markdown document into sections and merges them into, or replaces, a game's rules.
+// A dry run only previews the result.
+func (s *GameService) ImportRules(gameID uint, req *ImportRulesRequest, document []byte, userID uint) (*domain.RulesImport, error) {
+	g, user, err := s.editableGame(gameID, userID, "edit the rules of this game")
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := rulebook.ParseMarkdown(document)
+	if err != nil {
+		return nil, err
+	}
+
+	result := g.ImportRules(sections, req.Mode == "replace", user)
+	if req.DryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	// And save
+	err = s.GameRepository.ApplyRulesChanges(result.Changes)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListRevisions returns a page of the history of a game's rules, newest first
+func (s *GameService) ListRevisions(gameID uint, req *ListRevisionsRequest) ([]domain.RulesRevision, int, error) {
+	// Limit our limit
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	revisions, total, err := s.GameRepository.RevisionsOfGame(gameID, req.SectionID, req.Limit, req.Offset)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+// RulesDiff compares a game's rules at two points in time, section by section
+func (s *GameService) RulesDiff(gameID uint, req *RulesDiffRequest) ([]game.SectionDiff, error) {
+	revisions, err := s.rulesHistory(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := rulesAt(revisions, req.From, req.FromDate)
+	if err != nil {
+		return nil, err
+	}
+
+	to := domain.RulesAtTime(revisions, time.Now())
+	if req.To != 0 || req.ToDate != "" {
+		to, err = rulesAt(revisions, req.To, req.ToDate)
+		if err != nil {
 			return nil, err
 		}
 	}
 
-	if len(req.Designers) > 0 {
-		designers := []domain.User{}
-		for _, designerID := range req.Designers {
-			designer, err := s.UserRepository.UserOfID(designerID)
+	return domain.DiffRules(from, to), nil
+}
+
+// ExportRules assembles a game's rules into a single document with a title page and table of contents
+func (s *GameService) ExportRules(gameID uint, req *ExportRulesRequest) (*domain.Game, []byte, error) {
+	g, err := s.GameRepository.GameOfID(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if g == nil {
+		return nil, nil, domain.GameNotFound{ProvidedID: gameID}
+	}
+
+	rules, err := s.GameRepository.RulesOfGame(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	document, err := rulebook.Render(g.Rulebook(rules), rulebook.Format(req.Format))
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	return g, document, nil
+}
+
+// RestoreRevision puts a rules section back the way it was at an earlier revision
+func (s *GameService) RestoreRevision(gameID, revisionID uint, userID uint) (*game.RulesSection, error) {
+	g, user, err := s.editableGame(gameID, userID, "edit the rules of this game")
+	if err != nil {
+		return nil, err
+	}
+
+	earlier, err := s.GameRepository.RevisionOfID(revisionID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if earlier == nil {
+		return nil, game.RevisionNotFound{ProvidedID: revisionID}
+	}
+
+	section, err := g.RestoreRulesRevision(earlier)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := domain.ReviseRulesSection(section, user)
+	revision.RestoredFromID = &earlier.ID
+
+	// And save
+	err = s.GameRepository.SaveRulesSection(section, revision)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// rulesHistory pulls every revision of a game's rules. Sections written before revisions were recorded
+// have no history, so they're treated as having always been the way they are now.
+func (s *GameService) rulesHistory(gameID uint) ([]domain.RulesRevision, error) {
+	revisions, _, err := s.GameRepository.RevisionsOfGame(gameID, 0, -1, 0)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	rules, err := s.GameRepository.RulesOfGame(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	revised := map[uint]bool{}
+	for _, r := range revisions {
+		revised[r.SectionID] = true
+	}
+
+	for _, section := range rules {
+		if !revised[section.ID] {
+			revisions = append(revisions, domain.RulesRevision{
+				GameID:    gameID,
+				SectionID: section.ID,
+				Title:     section.Title,
+				Content:   section.Content,
+				OrderBy:   section.OrderBy,
+			})
+		}
+	}
+
+	return revisions, nil
+}
+
+// rulesAt works out the rules right after a revision, or at the end of a date. Revisions have to be part of
+// the game's history.
+func rulesAt(revisions []domain.RulesRevision, revisionID uint, date string) ([]domain.RulesRevision, error) {
+	if revisionID != 0 {
+		for _, r := range revisions {
+			if r.ID == revisionID {
+				return domain.RulesAtRevision(revisions, revisionID), nil
+			}
+		}
+
+		return nil, game.RevisionNotFound{ProvidedID: revisionID}
+	}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RulesAtTime(revisions, day.AddDate(0, 0, 1).Add(-time.Nanosecond)), nil
+}
+
+// ChangeStatus moves a game along its lifecycle and records the change in its status history
+func (s *GameService) ChangeStatus(gameID uint, req *ChangeStatusRequest, userID uint) (*domain.Game, *domain.StatusChange, error) {
+	g, user, err := s.editableGame(gameID, userID, "change the status of this game")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var contractDate *time.Time
+	if req.ContractDate != "" {
+		date, err := time.Parse("2006-01-02", req.ContractDate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		contractDate = &date
+	}
+
+	var p *domain.Publisher
+	if req.PublisherID != 0 {
+		p, err = s.PublisherRepository.PublisherOfID(req.PublisherID)
+		if err != nil {
+			s.Logger.Error(err.Error())
+			return nil, nil, err
+		}
+
+		if p == nil {
+			return nil, nil, domain.PublisherNotFound{ProvidedID: req.PublisherID}
+		}
+
+		// Designers can't attribute a contract to a publisher who never took the game on
+		if req.Status == string(game.Signed) {
+			submissions, err := s.SubmissionRepository.SubmissionsOfGame(g.ID)
 			if err != nil {
 				s.Logger.Error(err.Error())
-				return nil, err
+				return nil, nil, err
 			}
 
-			designers = append(designers, *designer)
+			if !domain.AcceptedBy(p, submissions) {
+				return nil, nil, publisher.NotAccepted{Publisher: p.Name}
+			}
 		}
+	}
 
-		game.ReplaceDesigners(designers)
+	change, err := g.ChangeStatus(req.Status, user, req.Note, p, contractDate)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if req.Stats != nil {
-		game.UpdateStats(req.Stats.MinPlayers, req.Stats.MaxPlayers, req.Stats.MinAge, req.Stats.EstimatedPlaytime)
+	err = s.GameRepository.SaveStatusChange(g, change)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
 	}
 
-	if req.Mechanics != nil {
-		game.ReplaceMechanics(req.Mechanics)
+	// Let the other designers know
+	event := domain.GameStatusChanged(g, change)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	return g, change, nil
+}
+
+// StatusHistory returns every status change of a game, oldest first
+func (s *GameService) StatusHistory(gameID uint) ([]domain.StatusChange, error) {
+	changes, err := s.GameRepository.StatusHistory(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
 	}
 
-	if req.TTSMod != 0 {
-		game.LinkTabletopSimulatorMod(req.TTSMod)
+	return changes, nil
+}
+
+// CreateVersion snapshots the game's current stats, mechanics, rules and files. Playtests registered
+// from now on are pinned to the new version.
+func (s *GameService) CreateVersion(gameID uint, req *CreateVersionRequest, userID uint) (*domain.GameVersion, error) {
+	game, _, err := s.editableGame(gameID, userID, "version this game")
+	if err != nil {
+		return nil, err
 	}
 
-	// And save
-	err = s.GameRepository.Save(game)
+	version, err := game.Snapshot(req.Name, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.GameRepository.SaveVersion(game, version)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	return game, nil
+	return version, nil
 }
 
-func (s *GameService) ListAvailableMechanics() []string {
-	return game.AvailableMechanics()
+// ListVersions returns every version of a game, newest first
+func (s *GameService) ListVersions(gameID uint) ([]domain.GameVersion, error) {
+	versions, err := s.GameRepository.VersionsOfGame(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetVersion returns a specific version of a game, including its rules and files
+func (s *GameService) GetVersion(gameID, versionID uint) (*domain.GameVersion, error) {
+	version, err := s.GameRepository.VersionOfID(versionID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if version == nil || version.GameID != gameID {
+		return nil, nil
+	}
+
+	return version, nil
+}
+
+// SanitizeStoredContent re-sanitizes overviews and rules written before sanitizing on write, returning how
+// many were changed. It's safe to run more than once.
+func (s *GameService) SanitizeStoredContent() (int, error) {
+	changed, err := s.GameRepository.RewriteContent(func(content string) string {
+		sanitized, _ := game.SanitizeHTML(content)
+		return sanitized
+	})
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return changed, err
+	}
+
+	return changed, nil
+}
+
+// editableGame pulls up a game, along with the user, provided the user may update it
+func (s *GameService) editableGame(gameID, userID uint, action string) (*domain.Game, *domain.User, error) {
+	g, err := s.GameRepository.GameOfID(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if g == nil {
+		return nil, nil, domain.GameNotFound{ProvidedID: gameID}
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if !g.MayBeUpdatedBy(user) {
+		return nil, nil, domain.Forbidden{Action: action}
+	}
+
+	return g, user, nil
 }
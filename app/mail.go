@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"html/template"
+	"strings"
 	"time"
 
 	"github.com/mailgun/mailgun-go/v4"
@@ -74,6 +75,199 @@ func (s *MailService) SendPasswordResetEmail(email, name, otp string) error {
 	return s.send(email, "Password reset requested", buf.String())
 }
 
+// SendWaitlistPromotionEmail lets a user know a seat opened up for them in a playtest they were waiting on
+func (s *MailService) SendWaitlistPromotionEmail(email, name, game string, date time.Time) error {
+	templateData := struct {
+		Name string
+		Game string
+		Date string
+	}{
+		Name: name,
+		Game: game,
+		Date: date.Format("Monday, January 2"),
+	}
+
+	tpl := s.Templates["email/waitlist-promoted"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, "You're in! A seat opened up for "+game, buf.String())
+}
+
+// SendPlaytestCancelledEmail lets a player know a playtest they signed up for has been called off
+func (s *MailService) SendPlaytestCancelledEmail(email, name, game string, date time.Time, reason string) error {
+	templateData := struct {
+		Name   string
+		Game   string
+		Date   string
+		Reason string
+	}{
+		Name:   name,
+		Game:   game,
+		Date:   date.Format("Monday, January 2"),
+		Reason: reason,
+	}
+
+	tpl := s.Templates["email/playtest-cancelled"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, "Cancelled: the playtest of "+game, buf.String())
+}
+
+// SendPlaytestRescheduledEmail lets a player know a playtest they signed up for has moved to another date
+func (s *MailService) SendPlaytestRescheduledEmail(email, name, game string, from, to time.Time, reason string) error {
+	templateData := struct {
+		Name   string
+		Game   string
+		From   string
+		Date   string
+		Reason string
+	}{
+		Name:   name,
+		Game:   game,
+		From:   from.Format("Monday, January 2"),
+		Date:   to.Format("Monday, January 2"),
+		Reason: reason,
+	}
+
+	tpl := s.Templates["email/playtest-rescheduled"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, "Rescheduled: the playtest of "+game, buf.String())
+}
+
+// SendDesignerInvitedEmail lets someone know they've been invited to co-design a game. People without an
+// account yet have no name.
+func (s *MailService) SendDesignerInvitedEmail(email, name, game, invitedBy string) error {
+	templateData := struct {
+		Name      string
+		Game      string
+		InvitedBy string
+	}{
+		Name:      name,
+		Game:      game,
+		InvitedBy: invitedBy,
+	}
+
+	tpl := s.Templates["email/designer-invited"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, invitedBy+" invited you to co-design "+game, buf.String())
+}
+
+// SendInvitationAnsweredEmail lets a designer know someone answered an invitation to co-design their game
+func (s *MailService) SendInvitationAnsweredEmail(email, name, game, invitee, status string) error {
+	templateData := struct {
+		Name    string
+		Game    string
+		Invitee string
+		Status  string
+	}{
+		Name:    name,
+		Game:    game,
+		Invitee: invitee,
+		Status:  status,
+	}
+
+	tpl := s.Templates["email/invitation-answered"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, invitee+" "+strings.ToLower(status)+" your invitation to "+game, buf.String())
+}
+
+// SendGameStatusChangedEmail lets a designer know one of their games has moved along its lifecycle
+func (s *MailService) SendGameStatusChangedEmail(email, name, game, changedBy, from, to, publisher, note string) error {
+	templateData := struct {
+		Name      string
+		Game      string
+		ChangedBy string
+		From      string
+		To        string
+		Publisher string
+		Note      string
+	}{
+		Name:      name,
+		Game:      game,
+		ChangedBy: changedBy,
+		From:      from,
+		To:        to,
+		Publisher: publisher,
+		Note:      note,
+	}
+
+	tpl := s.Templates["email/game-status-changed"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, game+" is now "+to, buf.String())
+}
+
+// SendSubmissionReceivedEmail lets a publisher's member know a game has been submitted for review
+func (s *MailService) SendSubmissionReceivedEmail(email, name, game, publisher, designer, pitch string) error {
+	templateData := struct {
+		Name      string
+		Game      string
+		Publisher string
+		Designer  string
+		Pitch     string
+	}{
+		Name:      name,
+		Game:      game,
+		Publisher: publisher,
+		Designer:  designer,
+		Pitch:     pitch,
+	}
+
+	tpl := s.Templates["email/submission-received"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, "New submission: "+game, buf.String())
+}
+
+// SendSubmissionUpdatedEmail lets either side of a submission know it's moved along in review
+func (s *MailService) SendSubmissionUpdatedEmail(email, name, game, publisher, stage, decision string) error {
+	templateData := struct {
+		Name      string
+		Game      string
+		Publisher string
+		Stage     string
+		Decision  string
+	}{
+		Name:      name,
+		Game:      game,
+		Publisher: publisher,
+		Stage:     stage,
+		Decision:  decision,
+	}
+
+	tpl := s.Templates["email/submission-updated"]
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, templateData); err != nil {
+		return err
+	}
+
+	return s.send(email, "Update on "+game+" at "+publisher, buf.String())
+}
+
 func (s *MailService) send(toAddress, subject, body string) error {
 	message := s.MailClient.NewMessage(
 		s.FromAddress,
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"go.uber.org/zap"
+)
+
+type (
+	// MechanicService curates the taxonomy of mechanics games are tagged with
+	MechanicService struct {
+		MechanicRepository domain.MechanicRepository
+		UserRepository     domain.UserRepository
+		Logger             *zap.Logger
+	}
+
+	// Request DTOs
+
+	// CreateMechanicRequest params for adding a mechanic to the taxonomy
+	CreateMechanicRequest struct {
+		Name        string   `json:"name" binding:"required" example:"Worker Placement"`
+		Description string   `json:"description" example:"Players take turns placing workers on spaces to take actions"`
+		Synonyms    []string `json:"synonyms" example:"Action Drafting"`
+		ParentID    *uint    `json:"parent_id" example:"120"`
+	}
+
+	// MergeMechanicRequest params for folding one mechanic into another
+	MergeMechanicRequest struct {
+		Into uint `json:"into" binding:"required" example:"123"`
+	}
+
+	// Response DTOs
+
+	// MechanicUsage a mechanic along with how many games are tagged with it
+	MechanicUsage struct {
+		domain.Mechanic
+		Games int `json:"games" example:"12"`
+	}
+
+	// ListMechanicsResponse wrapper for a listing of mechanics
+	ListMechanicsResponse struct {
+		Mechanics []MechanicUsage `json:"mechanics"`
+	}
+
+	// MechanicResponse wrapper around a single mechanic
+	MechanicResponse struct {
+		Mechanic *domain.Mechanic `json:"mechanic"`
+	}
+)
+
+// ListMechanics returns the whole taxonomy, sorted by name, with how many games use each mechanic
+func (s *MechanicService) ListMechanics() ([]MechanicUsage, error) {
+	mechanics, err := s.MechanicRepository.AllMechanics()
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	usage, err := s.MechanicRepository.MechanicUsage()
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	listing := []MechanicUsage{}
+	for _, m := range mechanics {
+		listing = append(listing, MechanicUsage{Mechanic: m, Games: usage[m.Name]})
+	}
+
+	return listing, nil
+}
+
+// CreateMechanic adds a new mechanic to the taxonomy. Only admins may do so.
+func (s *MechanicService) CreateMechanic(req *CreateMechanicRequest, userID uint) (*domain.Mechanic, error) {
+	if err := s.requireAdmin(userID, "add mechanics"); err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := s.MechanicRepository.AllMechanics()
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	mechanic, err := domain.NewMechanic(taxonomy, req.Name, req.Description, req.Synonyms, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.MechanicRepository.Save(mechanic)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return mechanic, nil
+}
+
+// MergeMechanic folds one mechanic into another, retagging every game that used it. Only admins may do so.
+func (s *MechanicService) MergeMechanic(mechanicID uint, req *MergeMechanicRequest, userID uint) (*domain.Mechanic, error) {
+	if err := s.requireAdmin(userID, "merge mechanics"); err != nil {
+		return nil, err
+	}
+
+	source, err := s.mechanicOfID(mechanicID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.mechanicOfID(req.Into)
+	if err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := s.MechanicRepository.AllMechanics()
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if err := target.Merge(source, taxonomy); err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.MechanicRepository.Merge(target, source)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (s *MechanicService) mechanicOfID(id uint) (*domain.Mechanic, error) {
+	mechanic, err := s.MechanicRepository.MechanicOfID(id)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if mechanic == nil {
+		return nil, domain.MechanicNotFound{ProvidedID: id}
+	}
+
+	return mechanic, nil
+}
+
+// requireAdmin makes sure the user is an admin before curating the taxonomy
+func (s *MechanicService) requireAdmin(userID uint, action string) error {
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return err
+	}
+
+	if user == nil || !user.Admin {
+		return domain.Forbidden{Action: action}
+	}
+
+	return nil
+}
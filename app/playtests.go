@@ -1,16 +1,20 @@
 package app
 
 import (
-	"fmt"
+	"errors"
 	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/credit"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
 	"go.uber.org/zap"
 )
 
 type (
 	// PlaytestService handles general interactions with games
 	PlaytestService struct {
+		CreditRepository   domain.CreditRepository
 		EventRepository    domain.EventRepository
 		GameRepository     domain.GameRepository
 		PlaytestRepository domain.PlaytestRepository
@@ -22,8 +26,19 @@ type (
 
 	// ListPlaytestsRequest query params
 	ListPlaytestsRequest struct {
-		Date    string `form:"date" binding:"required"`
-		EventID uint   `form:"event_id"`
+		Date             string `form:"date" binding:"required"`
+		EventID          uint   `form:"event_id"`
+		IncludeCancelled bool   `form:"include_cancelled" example:"true"`
+	}
+
+	// GamePlaytestsRequest query params for a game's playtest history
+	GamePlaytestsRequest struct {
+		From      string `form:"from" example:"2020-12-01"`
+		To        string `form:"to" example:"2020-12-31"`
+		EventID   uint   `form:"event_id" example:"123"`
+		VersionID uint   `form:"version_id" example:"123"`
+		Limit     int    `form:"limit" example:"100"`
+		Offset    int    `form:"offset" example:"50"`
 	}
 
 	// RegisterGameRequest params required for registering for a playtest
@@ -31,6 +46,7 @@ type (
 		GameID              uint   `json:"game" binding:"required"`
 		EventID             uint   `json:"event"`
 		Date                string `json:"date" binding:"required"`
+		Slot                uint   `json:"slot" example:"2"`
 		MinNumberOfPlayers  uint   `json:"min_players" binding:"required" example:"3"`
 		MaxNumberOfPlayers  uint   `json:"max_players" binding:"required" example:"5"`
 		Duration            uint   `json:"duration" binding:"required" example:"60"`
@@ -40,18 +56,99 @@ type (
 		TTSPassword         string `json:"tts_password" example:"password"`
 	}
 
+	// RotateTTSPasswordRequest wraps the new password for a playtest's TTS server. A blank password
+	// generates a random one.
+	RotateTTSPasswordRequest struct {
+		Password string `json:"password" example:"new-password"`
+	}
+
 	// AssignPlaytestLocationRequest wraps the table assignment for a playtest
 	AssignPlaytestLocationRequest struct {
 		Table string `json:"table" binding:"required" example:"1"`
 	}
 
+	// StartPlaytestRequest lists the players who showed up. Leave attendees out to check in every player.
+	StartPlaytestRequest struct {
+		Attendees []uint `json:"attendees" example:"1,2,3"`
+	}
+
+	// SeatResult how a single player fared in a playtest
+	SeatResult struct {
+		PlayerID uint `json:"player" binding:"required" example:"123"`
+		Seat     uint `json:"seat" binding:"required,min=1" example:"1"`
+		Score    *int `json:"score" example:"42"`
+		Winner   bool `json:"winner" example:"true"`
+	}
+
+	// FinishPlaytestRequest optional outcome data for a playtest. Ending is Completed when left out.
+	FinishPlaytestRequest struct {
+		Ending  string       `json:"ending" binding:"omitempty,oneof=Completed Abandoned" example:"Completed"`
+		Results []SeatResult `json:"results" binding:"dive"`
+	}
+
+	// CancelPlaytestRequest wraps the reason a playtest was called off
+	CancelPlaytestRequest struct {
+		Reason string `json:"reason" binding:"required" example:"The designer is unwell"`
+	}
+
+	// ReschedulePlaytestRequest params for moving a playtest to another date. Slot only applies
+	// to playtests at an event, where 0 picks the first slot of the day.
+	ReschedulePlaytestRequest struct {
+		Date   string `json:"date" binding:"required" example:"2020-12-23"`
+		Slot   uint   `json:"slot" example:"2"`
+		Reason string `json:"reason" binding:"required" example:"Moved to make room for a tournament"`
+	}
+
+	// ProposeTablePlanRequest params for automatically assigning an event day's playtests to tables.
+	// Plans start when the event opens that day unless a start_time is given. Players whose reliability
+	// falls below min_reliability are flagged.
+	ProposeTablePlanRequest struct {
+		Date           string           `json:"date" binding:"required" example:"2020-12-16"`
+		StartTime      string           `json:"start_time" example:"18:00"`
+		Tables         []playtest.Table `json:"tables" binding:"required,min=1"`
+		MinReliability float64          `json:"min_reliability" binding:"min=0,max=1" example:"0.75"`
+	}
+
+	// TableAssignment places a single playtest at a table, optionally at a particular time
+	TableAssignment struct {
+		PlaytestID uint      `json:"playtest" binding:"required" example:"123"`
+		Table      string    `json:"table" binding:"required" example:"Table 1"`
+		StartsAt   time.Time `json:"starts_at" example:"2020-12-16T19:00:00-08:00"`
+	}
+
+	// CommitTablePlanRequest params for committing a (possibly tweaked) table plan
+	CommitTablePlanRequest struct {
+		Assignments []TableAssignment `json:"assignments" binding:"required,min=1,dive"`
+	}
+
 	// Response DTOs
 
+	// TablePlanResponse proposed table assignments, along with any players who often don't show up
+	TablePlanResponse struct {
+		Plan    playtest.Plan          `json:"plan"`
+		Flagged []playtest.Reliability `json:"flagged"`
+	}
+
 	// ListPlaytestsResponse playtests wrapper
 	ListPlaytestsResponse struct {
 		Playtests []domain.Playtest `json:"playtests"`
 	}
 
+	// PlaytestsOnDateResponse playtests on a date, along with the same playtests grouped by slot
+	PlaytestsOnDateResponse struct {
+		Playtests []domain.Playtest     `json:"playtests"`
+		Slots     []domain.PlaytestSlot `json:"slots"`
+	}
+
+	// GamePlaytestsResponse paginated playtest history for a game, along with metrics across the whole history
+	GamePlaytestsResponse struct {
+		Playtests []domain.Playtest       `json:"playtests"`
+		Total     int                     `json:"total" example:"1000"`
+		Limit     int                     `json:"limit" example:"100"`
+		Offset    int                     `json:"offset" example:"50"`
+		Metrics   playtest.HistoryMetrics `json:"metrics"`
+	}
+
 	// PlaytestResponse playtest wrapper
 	PlaytestResponse struct {
 		Playtest *domain.Playtest `json:"playtest"`
@@ -59,23 +156,106 @@ type (
 )
 
 // ListPlaytests returns all the playtests scheduled on the specified date. Optionally by event.
-func (s *PlaytestService) ListPlaytests(req *ListPlaytestsRequest) ([]domain.Playtest, error) {
+// Location secrets are only included for playtests the user is taking part in; userID may be 0 for anonymous users.
+func (s *PlaytestService) ListPlaytests(req *ListPlaytestsRequest, userID uint) ([]domain.Playtest, error) {
+	viewer, err := s.viewer(userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Fetch playtests
-	date, err := time.Parse("2006-01-02", req.Date)
+	start, end, err := s.PlaytestDay(req.EventID, req.Date)
 	if err != nil {
-		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	playtests, err := s.PlaytestRepository.PlaytestsOnDate(date, req.EventID)
+	playtests, err := s.PlaytestRepository.PlaytestsBetween(start, end, req.EventID, req.IncludeCancelled)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
+	for i := range playtests {
+		playtests[i].RedactSecretsFor(viewer)
+	}
+
 	return playtests, nil
 }
 
+// PlaytestDay works out when a day of playtests starts and ends. The date is a day at the event, wherever it's
+// held, so it's measured in the event's time zone. Days outside of an event are measured in UTC.
+func (s *PlaytestService) PlaytestDay(eventID uint, date string) (time.Time, time.Time, error) {
+	e, err := eventOfID(s.EventRepository, eventID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	start, end := e.Day(day)
+
+	return start, end, nil
+}
+
+// GamePlaytests returns a page of the game's playtest history, along with metrics for every matching playtest.
+// Location secrets are only included for playtests the user is taking part in; userID may be 0 for anonymous users.
+func (s *PlaytestService) GamePlaytests(gameID uint, req *GamePlaytestsRequest, userID uint) ([]domain.Playtest, int, *playtest.HistoryMetrics, error) {
+	viewer, err := s.viewer(userID)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Limit our limit
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	g, err := s.GameRepository.GameOfID(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, 0, nil, err
+	}
+
+	if g == nil {
+		return nil, 0, nil, domain.GameNotFound{ProvidedID: gameID}
+	}
+
+	e, err := eventOfID(s.EventRepository, req.EventID)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	from, to, err := dateRange(e, req.From, req.To)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	playtests, total, err := s.PlaytestRepository.PlaytestsOfGame(gameID, from, to, req.EventID, req.VersionID, req.Limit, req.Offset)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, 0, nil, err
+	}
+
+	// Metrics cover the full history, not just the current page
+	metrics, err := s.PlaytestRepository.HistoryOfGame(gameID, from, to, req.EventID, req.VersionID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, 0, nil, err
+	}
+
+	for i := range playtests {
+		playtests[i].RedactSecretsFor(viewer)
+	}
+
+	return playtests, total, &metrics, nil
+}
+
 // RegisterGame sets up a new playtest for a game at a specific time. It can optionally be tied to an event
 func (s *PlaytestService) RegisterGame(req *RegisterGameRequest, userID uint) (*domain.Playtest, error) {
 	// Pull up our user & game and make sure they're compatible
@@ -91,8 +271,12 @@ func (s *PlaytestService) RegisterGame(req *RegisterGameRequest, userID uint) (*
 		return nil, err
 	}
 
-	if user == nil || game == nil || !game.MayBeUpdatedBy(user) {
-		return nil, fmt.Errorf("you're not allowed to register this game for playtesting")
+	if game == nil {
+		return nil, domain.GameNotFound{ProvidedID: req.GameID}
+	}
+
+	if !game.MayBeUpdatedBy(user) {
+		return nil, domain.Forbidden{Action: "register this game for playtesting"}
 	}
 
 	// Create the playtest
@@ -103,6 +287,10 @@ func (s *PlaytestService) RegisterGame(req *RegisterGameRequest, userID uint) (*
 			s.Logger.Error(err.Error())
 			return nil, err
 		}
+
+		if event == nil {
+			return nil, domain.EventNotFound{ProvidedID: req.EventID}
+		}
 	}
 
 	date, err := time.Parse("2006-01-02", req.Date)
@@ -111,10 +299,11 @@ func (s *PlaytestService) RegisterGame(req *RegisterGameRequest, userID uint) (*
 		return nil, err
 	}
 
-	playtest := domain.RegisterGame(
+	playtest, err := domain.RegisterGame(
 		game,
 		event,
 		date,
+		req.Slot,
 		req.MinNumberOfPlayers,
 		req.MaxNumberOfPlayers,
 		req.Duration,
@@ -123,40 +312,147 @@ func (s *PlaytestService) RegisterGame(req *RegisterGameRequest, userID uint) (*
 		req.TTSServer,
 		req.TTSPassword,
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	snapshot, err := s.snapshotForPlaytest(game, playtest)
 	if err != nil {
-		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
+	// And save. Some events charge credits to register, so the balance is checked as the playtest is
+	// saved, where two registrations can't spend the same credits. Any new version is only kept when
+	// the registration goes through.
+	err = s.PlaytestRepository.Register(playtest, snapshot, user.ID, func(balance int) (*domain.CreditTransaction, error) {
+		return domain.SpendCredits(user, balance, event)
+	})
+	if err != nil {
+		if !errors.As(err, &credit.InsufficientCredits{}) {
+			s.Logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	s.broadcast(playtest)
+
 	return playtest, nil
 }
 
-// AssignLocation assigns a playtest to a table (real or virtual)
-func (s *PlaytestService) AssignLocation(playtestID uint, req *AssignPlaytestLocationRequest, userID uint) (*domain.Playtest, error) {
-	// Pull up our user & make sure they're allowed to assign locations
-	user, err := s.UserRepository.UserOfID(userID)
+// ProposeTablePlan works out a table for every registered playtest at an event on a date. Nothing is saved;
+// the facilitator reviews the plan and commits it with CommitTablePlan.
+func (s *PlaytestService) ProposeTablePlan(eventID uint, req *ProposeTablePlanRequest, userID uint) (*playtest.Plan, []playtest.Reliability, error) {
+	e, err := s.facilitatedEvent(eventID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Plans cover the event's hours that day, unless the facilitator wants to start later
+	start, closes, err := e.HoursOn(date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	day, end := e.Day(date)
+	if req.StartTime != "" {
+		t, err := time.Parse("15:04", req.StartTime)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		start = day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+
+	playtests, err := s.PlaytestRepository.PlaytestsBetween(day, end, e.ID, false)
 	if err != nil {
 		s.Logger.Error(err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
-	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	plan := domain.PlanTables(playtests, req.Tables, start, closes, e.Duration)
+
+	// Flag anyone who often doesn't show up, so the facilitator can plan around them
+	players := []uint{}
+	for _, p := range playtests {
+		for _, player := range p.Players {
+			players = append(players, player.ID)
+		}
+	}
+
+	records, err := s.PlaytestRepository.AttendanceOfUsers(players)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	threshold := req.MinReliability
+	if threshold == 0 {
+		threshold = playtest.LowReliability
+	}
+
+	flagged := domain.FlagUnreliablePlayers(playtests, domain.ReliabilityOf(players, records), threshold)
+
+	return &plan, flagged, nil
+}
+
+// CommitTablePlan assigns each playtest to its table. Every playtest must belong to the event.
+func (s *PlaytestService) CommitTablePlan(eventID uint, req *CommitTablePlanRequest, userID uint) ([]domain.Playtest, error) {
+	e, err := s.facilitatedEvent(eventID, userID)
 	if err != nil {
+		return nil, err
+	}
+
+	playtests := []domain.Playtest{}
+	for _, assignment := range req.Assignments {
+		p, err := s.PlaytestRepository.PlaytestOfID(assignment.PlaytestID)
+		if err != nil {
+			s.Logger.Error(err.Error())
+			return nil, err
+		}
+
+		if p == nil || p.EventID == nil || *p.EventID != e.ID {
+			return nil, domain.PlaytestNotFound{ProvidedID: assignment.PlaytestID}
+		}
+
+		if err := p.AssignTableAt(assignment.Table, assignment.StartsAt); err != nil {
+			return nil, err
+		}
+
+		playtests = append(playtests, *p)
+	}
+
+	// Only save once we know every assignment is valid, and then all at once
+	if err := s.PlaytestRepository.SaveAll(playtests); err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	if user == nil || playtest == nil {
-		return nil, fmt.Errorf("you're not allowed to assign locations")
+	for i := range playtests {
+		s.broadcast(&playtests[i])
 	}
 
-	playtest.AssignTable(req.Table)
+	return playtests, nil
+}
+
+// AssignLocation assigns a playtest to a table (real or virtual)
+func (s *PlaytestService) AssignLocation(playtestID uint, req *AssignPlaytestLocationRequest, userID uint) (*domain.Playtest, error) {
+	playtest, err := s.managedPlaytest(playtestID, userID, "assign a location to this playtest")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := playtest.AssignTable(req.Table); err != nil {
+		return nil, err
+	}
 
 	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	err = s.save(playtest)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
@@ -167,7 +463,7 @@ func (s *PlaytestService) AssignLocation(playtestID uint, req *AssignPlaytestLoc
 
 // AddPlayer adds a player to the playtest
 func (s *PlaytestService) AddPlayer(playtestID uint, userID uint) (*domain.Playtest, error) {
-	// Pull up our user & make sure they're allowed to join
+	// Players only ever join themselves
 	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
@@ -180,25 +476,30 @@ func (s *PlaytestService) AddPlayer(playtestID uint, userID uint) (*domain.Playt
 		return nil, err
 	}
 
-	if user == nil || playtest == nil {
-		return nil, fmt.Errorf("you're not allowed to join this playtest")
+	if playtest == nil {
+		return nil, domain.PlaytestNotFound{ProvidedID: playtestID}
 	}
 
-	playtest.AddPlayer(user)
+	if _, err := playtest.AddPlayer(user); err != nil {
+		return nil, err
+	}
 
 	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	err = s.save(playtest)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
+	// Waitlisted users don't get the password until they're promoted
+	playtest.RedactSecretsFor(user)
+
 	return playtest, nil
 }
 
 // RemovePlayer removes a player from a playtest
 func (s *PlaytestService) RemovePlayer(playtestID uint, userID uint) (*domain.Playtest, error) {
-	// Pull up our user & make sure they're allowed to assign locations
+	// Players only ever leave themselves
 	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
@@ -211,45 +512,97 @@ func (s *PlaytestService) RemovePlayer(playtestID uint, userID uint) (*domain.Pl
 		return nil, err
 	}
 
-	if user == nil || playtest == nil {
-		return nil, fmt.Errorf("you're not allowed to leave this playtest")
+	if playtest == nil {
+		return nil, domain.PlaytestNotFound{ProvidedID: playtestID}
 	}
 
-	playtest.RemovePlayer(user)
+	promoted := playtest.RemovePlayer(user)
 
 	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	err = s.save(playtest)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
+	if promoted != nil {
+		event := domain.PlayerPromoted(playtest, promoted)
+		pubsub.Instance.Publish(event.Name, event.Data)
+	}
+
+	playtest.RedactSecretsFor(user)
+
 	return playtest, nil
 }
 
-// StartPlaytest will set the time the playtest started to now
-func (s *PlaytestService) StartPlaytest(playtestID uint, userID uint) (*domain.Playtest, error) {
-	// Pull up our user & make sure they're allowed to assign locations
+// EncryptStoredSecrets encrypts TTS passwords saved as plaintext before encryption at rest, returning how many
+// were changed. It's safe to run more than once.
+func (s *PlaytestService) EncryptStoredSecrets() (int, error) {
+	changed, err := s.PlaytestRepository.EncryptSecrets()
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return changed, err
+	}
+
+	return changed, nil
+}
+
+// RotateTTSPassword replaces the password for a playtest's TTS server. Only designers may rotate it.
+func (s *PlaytestService) RotateTTSPassword(playtestID uint, req *RotateTTSPasswordRequest, userID uint) (*domain.Playtest, error) {
 	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	p, err := s.PlaytestRepository.PlaytestOfID(playtestID)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	if user == nil || playtest == nil {
-		return nil, fmt.Errorf("you're not allowed to assign locations")
+	if p == nil {
+		return nil, domain.PlaytestNotFound{ProvidedID: playtestID}
+	}
+
+	if !p.Game.MayBeUpdatedBy(user) {
+		return nil, domain.Forbidden{Action: "rotate the password for this playtest"}
+	}
+
+	password := playtest.Secret(req.Password)
+	if password == "" {
+		password, err = playtest.GeneratePassword(12)
+		if err != nil {
+			s.Logger.Error(err.Error())
+			return nil, err
+		}
 	}
 
-	playtest.Start()
+	p.RotateTTSPassword(password)
 
 	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	err = s.save(p)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// StartPlaytest will set the time the playtest started to now and check in the players who showed up
+func (s *PlaytestService) StartPlaytest(playtestID uint, req *StartPlaytestRequest, userID uint) (*domain.Playtest, error) {
+	playtest, err := s.managedPlaytest(playtestID, userID, "start this playtest")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := playtest.Start(req.Attendees); err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.save(playtest)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
@@ -260,62 +613,268 @@ func (s *PlaytestService) StartPlaytest(playtestID uint, userID uint) (*domain.P
 
 // StartFeedback will set the feedback time to now
 func (s *PlaytestService) StartFeedback(playtestID uint, userID uint) (*domain.Playtest, error) {
-	// Pull up our user & make sure they're allowed to assign locations
-	user, err := s.UserRepository.UserOfID(userID)
+	playtest, err := s.managedPlaytest(playtestID, userID, "start feedback for this playtest")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := playtest.StartFeedback(); err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.save(playtest)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	return playtest, nil
+}
+
+// FinishPlaytest will set the time the playtest finished to now, recording the outcome of the game if provided
+func (s *PlaytestService) FinishPlaytest(playtestID uint, req *FinishPlaytestRequest, userID uint) (*domain.Playtest, error) {
+	p, err := s.managedPlaytest(playtestID, userID, "finish this playtest")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Ending != "" || len(req.Results) > 0 {
+		ending, err := playtest.EndingFromString(req.Ending)
+		if err != nil {
+			return nil, err
+		}
+
+		results := []domain.SeatResult{}
+		for _, r := range req.Results {
+			results = append(results, domain.SeatResult{UserID: r.PlayerID, Seat: r.Seat, Score: r.Score, Winner: r.Winner})
+		}
+
+		if err := p.RecordOutcome(ending, results); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.Finish(); err != nil {
+		return nil, err
+	}
+
+	// And save, along with the credits earned by everyone who played someone else's game
+	err = s.PlaytestRepository.SaveWithCredits(p, domain.EarnCredits(p))
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	s.broadcast(p)
+
+	return p, nil
+}
+
+// CancelPlaytest calls off a playtest that hasn't started yet and lets its players know. Any credits
+// spent registering it are refunded.
+func (s *PlaytestService) CancelPlaytest(playtestID uint, req *CancelPlaytestRequest, userID uint) (*domain.Playtest, error) {
+	p, err := s.managedPlaytest(playtestID, userID, "cancel this playtest")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Cancel(req.Reason); err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.CreditRepository.TransactionsOfPlaytest(p.ID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// And save, along with the refunds
+	err = s.PlaytestRepository.SaveWithCredits(p, domain.RefundCredits(p, transactions))
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	if user == nil || playtest == nil {
-		return nil, fmt.Errorf("you're not allowed to assign locations")
+	s.broadcast(p)
+
+	// Let the players know
+	event := domain.PlaytestCancelled(p)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	return p, nil
+}
+
+// ReschedulePlaytest moves a playtest that hasn't started yet to another date and lets its players know
+func (s *PlaytestService) ReschedulePlaytest(playtestID uint, req *ReschedulePlaytestRequest, userID uint) (*domain.Playtest, error) {
+	p, err := s.managedPlaytest(playtestID, userID, "reschedule this playtest")
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, err
 	}
 
-	playtest.StartFeedback()
+	previous := p.ScheduledDate
+	if err := p.Reschedule(date, req.Slot, req.Reason); err != nil {
+		return nil, err
+	}
 
 	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	err = s.save(p, previous)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	return playtest, nil
+	// Let the players know
+	event := domain.PlaytestRescheduled(p, previous)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	return p, nil
+}
+
+// save stores the playtest and broadcasts the change to anyone watching the board for its date.
+// Playtests that moved should pass the dates they moved from.
+func (s *PlaytestService) save(p *domain.Playtest, previousDates ...time.Time) error {
+	if err := s.PlaytestRepository.Save(p); err != nil {
+		return err
+	}
+
+	s.broadcast(p, previousDates...)
+
+	return nil
 }
 
-// FinishPlaytest will set the time the playtest finished to now
-func (s *PlaytestService) FinishPlaytest(playtestID uint, userID uint) (*domain.Playtest, error) {
-	// Pull up our user & make sure they're allowed to assign locations
+// broadcast lets anyone watching the board for the playtest's date know it changed
+func (s *PlaytestService) broadcast(p *domain.Playtest, previousDates ...time.Time) {
+	event := domain.PlaytestUpdated(p, previousDates...)
+	pubsub.Instance.Publish(event.Name, event.Data)
+}
+
+// viewer pulls up the user looking at playtests, if there is one
+func (s *PlaytestService) viewer(userID uint) (*domain.User, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+
 	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	return user, nil
+}
+
+// snapshotForPlaytest returns the new version a playtest should be pinned to when the game has changed since
+// its current version, or was never versioned. It returns nil while the current version still matches.
+func (s *PlaytestService) snapshotForPlaytest(game *domain.Game, p *domain.Playtest) (*domain.GameVersion, error) {
+	var current *domain.GameVersion
+	if game.CurrentVersionID != nil {
+		var err error
+		current, err = s.GameRepository.VersionOfID(*game.CurrentVersionID)
+		if err != nil {
+			s.Logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	return game.SnapshotForPlaytest(current, p.ScheduledDate), nil
+}
+
+// eventOfID pulls up the event playtests are being filtered by. No event is needed when the ID is 0.
+func eventOfID(events domain.EventRepository, eventID uint) (*domain.Event, error) {
+	if eventID == 0 {
+		return nil, nil
+	}
+
+	e, err := events.EventOfID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if e == nil {
+		return nil, domain.EventNotFound{ProvidedID: eventID}
+	}
+
+	return e, nil
+}
+
+// dateRange turns a pair of dates into times covering both days in full, in the event's time zone: from the
+// start of the first day up to the start of the day after the last. Blank dates leave that end open.
+func dateRange(e *domain.Event, from, to string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	if from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return start, end, err
+		}
+
+		start, _ = e.Day(date)
+	}
+
+	if to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return start, end, err
+		}
+
+		_, end = e.Day(date)
+	}
+
+	return start, end, nil
+}
+
+// facilitatedEvent pulls up the event and makes sure the user facilitates it
+func (s *PlaytestService) facilitatedEvent(eventID uint, userID uint) (*domain.Event, error) {
+	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
-	if user == nil || playtest == nil {
-		return nil, fmt.Errorf("you're not allowed to assign locations")
+	e, err := s.EventRepository.EventOfID(eventID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
 	}
 
-	playtest.Finish()
+	if e == nil {
+		return nil, domain.EventNotFound{ProvidedID: eventID}
+	}
 
-	// And save
-	err = s.PlaytestRepository.Save(playtest)
+	if !e.MayBeUpdatedBy(user) {
+		return nil, domain.Forbidden{Action: "assign tables for this event"}
+	}
+
+	return e, nil
+}
+
+// managedPlaytest pulls up the playtest and makes sure the user is allowed to manage it.
+// The action is used to describe what the user was denied from doing.
+func (s *PlaytestService) managedPlaytest(playtestID uint, userID uint, action string) (*domain.Playtest, error) {
+	user, err := s.UserRepository.UserOfID(userID)
 	if err != nil {
 		s.Logger.Error(err.Error())
 		return nil, err
 	}
 
+	playtest, err := s.PlaytestRepository.PlaytestOfID(playtestID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if playtest == nil {
+		return nil, domain.PlaytestNotFound{ProvidedID: playtestID}
+	}
+
+	if !playtest.MayBeManagedBy(user) {
+		return nil, domain.Forbidden{Action: action}
+	}
+
 	return playtest, nil
 }
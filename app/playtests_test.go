@@ -0,0 +1,122 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"go.uber.org/zap"
+)
+
+// fixtureEvents stands in for the database, returning the same event for every ID
+type fixtureEvents struct {
+	event *domain.Event
+}
+
+func (r *fixtureEvents) ListEvents() ([]domain.Event, error) {
+	return []domain.Event{*r.event}, nil
+}
+
+func (r *fixtureEvents) EventOfID(id uint) (*domain.Event, error) {
+	return r.event, nil
+}
+
+func (r *fixtureEvents) Save(*domain.Event) error {
+	return nil
+}
+
+// fixtureUsers stands in for the database, returning the same user for every lookup
+type fixtureUsers struct {
+	user *domain.User
+}
+
+func (r *fixtureUsers) UserOfID(uint) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *fixtureUsers) UserOfEmail(string) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *fixtureUsers) UserOfVerificationID(string) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *fixtureUsers) UserOfOneTimePassword(string) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *fixtureUsers) ListUsers(name string, limit, offset int, sort string) ([]domain.User, int, error) {
+	return []domain.User{*r.user}, 1, nil
+}
+
+func (r *fixtureUsers) Save(*domain.User) error {
+	return nil
+}
+
+func TestProposeTablePlanDuringEventHours(t *testing.T) {
+	facilitator := domain.User{ID: 1}
+	eventStart := time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)
+
+	s := &PlaytestService{
+		EventRepository: &fixtureEvents{event: &domain.Event{
+			ID:           1,
+			Facilitators: []domain.User{facilitator},
+			Duration:     4 * time.Hour,
+			SlotLength:   2 * time.Hour,
+			RRule:        "DTSTART:20201202T180000Z\nRRULE:FREQ=WEEKLY;BYDAY=WE",
+		}},
+		PlaytestRepository: &fixturePlaytests{playtests: []domain.Playtest{
+			{
+				ID:            1,
+				State:         playtest.Registered,
+				ScheduledDate: eventStart,
+				Requirements:  playtest.Requirements{MinPlayers: 2, Duration: 60},
+			},
+			{
+				ID:            2,
+				State:         playtest.Registered,
+				Slot:          2,
+				ScheduledDate: eventStart.Add(2 * time.Hour),
+				Requirements:  playtest.Requirements{MinPlayers: 2, Duration: 90},
+			},
+		}},
+		UserRepository: &fixtureUsers{user: &facilitator},
+		Logger:         zap.NewNop(),
+	}
+
+	// Without a start time, the plan starts when the event does rather than at midnight
+	plan, _, err := s.ProposeTablePlan(1, &ProposeTablePlanRequest{
+		Date:   "2020-12-16",
+		Tables: []playtest.Table{{Name: "Big", Seats: 6}, {Name: "Small", Seats: 4}},
+	}, facilitator.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error planning tables: %s", err)
+	}
+
+	if len(plan.Unassigned) != 0 {
+		t.Fatalf("Expected every playtest to fit in the event, got %+v", plan.Unassigned)
+	}
+
+	starts := map[uint]time.Time{}
+	for _, a := range plan.Assignments {
+		starts[a.PlaytestID] = a.StartsAt
+	}
+
+	if !starts[1].Equal(eventStart) {
+		t.Errorf("Expected the first playtest to start with the event, got %s", starts[1])
+	}
+
+	if !starts[2].Equal(eventStart.Add(2 * time.Hour)) {
+		t.Errorf("Expected the slot playtest to start with its slot, got %s", starts[2])
+	}
+
+	// Days the event isn't on can't be planned
+	if _, _, err := s.ProposeTablePlan(1, &ProposeTablePlanRequest{
+		Date:   "2020-12-17",
+		Tables: []playtest.Table{{Name: "Only"}},
+	}, facilitator.ID); err == nil {
+		t.Error("Expected days without an occurrence to be rejected")
+	}
+}
@@ -0,0 +1,570 @@
+package app
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
+	"go.uber.org/zap"
+)
+
+type (
+	// PublisherService handles publishers and the games designers submit to them
+	PublisherService struct {
+		FileRepository       domain.FileRepository
+		GameRepository       domain.GameRepository
+		MechanicRepository   domain.MechanicRepository
+		PublisherRepository  domain.PublisherRepository
+		SubmissionRepository domain.SubmissionRepository
+		UserRepository       domain.UserRepository
+		Logger               *zap.Logger
+	}
+
+	// Request DTOs
+
+	// ListPublishersRequest query params for finding publishers. Providing a game only lists publishers whose
+	// criteria it fits.
+	ListPublishersRequest struct {
+		Accepting bool `form:"accepting" example:"true"`
+		GameID    uint `form:"game" example:"123"`
+	}
+
+	// CreatePublisherRequest params for creating a publisher. The owner runs the publisher and decides who else
+	// is a member; it defaults to whoever creates the publisher.
+	CreatePublisherRequest struct {
+		Name    string `json:"name" binding:"required" example:"Coin Flip Games"`
+		Website string `json:"website" binding:"omitempty,url" example:"https://coinflipgames.co"`
+		OwnerID uint   `json:"owner_id" example:"123"`
+	}
+
+	// UpdatePublisherRequest params for updating a publisher and what it's looking for. Members are managed
+	// on their own, see AddMemberRequest.
+	UpdatePublisherRequest struct {
+		Name     string    `json:"name" example:"Coin Flip Games"`
+		Website  string    `json:"website" binding:"omitempty,url" example:"https://coinflipgames.co"`
+		Criteria *Criteria `json:"criteria" binding:"omitempty,dive"`
+	}
+
+	// AddMemberRequest params for adding someone to a publisher
+	AddMemberRequest struct {
+		UserID uint `json:"user_id" binding:"required" example:"123"`
+	}
+
+	// Criteria params for what a publisher is looking for. Zero values mean the publisher doesn't mind.
+	Criteria struct {
+		AcceptingSubmissions bool     `json:"accepting_submissions" example:"true"`
+		Mechanics            []string `json:"mechanics" example:"['Deck Building', 'Worker Placement']"`
+		MinPlayers           int      `json:"min_players" binding:"min=0" example:"2"`
+		MaxPlayers           int      `json:"max_players" binding:"min=0" example:"6"`
+		MaxPlaytime          int      `json:"max_playtime" binding:"min=0" example:"60"`
+		Notes                string   `json:"notes" example:"Family weight games with a strong theme"`
+	}
+
+	// SubmitGameRequest params for pitching a game to a publisher
+	SubmitGameRequest struct {
+		GameID      uint   `json:"game_id" binding:"required" example:"123"`
+		SellSheetID uint   `json:"sell_sheet_id" binding:"required" example:"456"`
+		Pitch       string `json:"pitch" example:"A quick filler about racing snails"`
+	}
+
+	// ListSubmissionsRequest query params for a publisher's submissions
+	ListSubmissionsRequest struct {
+		Stage  string `form:"stage" binding:"omitempty,oneof=Submitted Reviewing PrototypeRequested Decided Withdrawn" example:"Reviewing"`
+		Limit  int    `form:"limit" example:"100"`
+		Offset int    `form:"offset" example:"50"`
+	}
+
+	// AdvanceSubmissionRequest params for moving a submission through review. Deciding on a submission
+	// requires accepting or declining it.
+	AdvanceSubmissionRequest struct {
+		Stage    string `json:"stage" binding:"required,oneof=Reviewing PrototypeRequested Decided" example:"Decided"`
+		Decision string `json:"decision" binding:"omitempty,oneof=Accepted Declined" example:"Accepted"`
+	}
+
+	// AddSubmissionNoteRequest params for keeping a private note about a submission
+	AddSubmissionNoteRequest struct {
+		Body string `json:"body" binding:"required" example:"Loved the theme, scoring felt swingy"`
+	}
+
+	// Response DTOs
+
+	// ListPublishersResponse wrapper for a listing of publishers
+	ListPublishersResponse struct {
+		Publishers []domain.Publisher `json:"publishers"`
+	}
+
+	// PublisherResponse wrapper around a publisher
+	PublisherResponse struct {
+		Publisher *domain.Publisher `json:"publisher"`
+	}
+
+	// ListSubmissionsResponse paginated submissions list
+	ListSubmissionsResponse struct {
+		Submissions []domain.Submission `json:"submissions"`
+		Total       int                 `json:"total" example:"1000"`
+		Limit       int                 `json:"limit" example:"100"`
+		Offset      int                 `json:"offset" example:"50"`
+	}
+
+	// SubmissionResponse wrapper around a submission
+	SubmissionResponse struct {
+		Submission *domain.Submission `json:"submission"`
+	}
+
+	// SubmissionNoteResponse wrapper around a note on a submission
+	SubmissionNoteResponse struct {
+		Note *domain.SubmissionNote `json:"note"`
+	}
+)
+
+// ListPublishers returns every publisher, sorted by name. Publishers can be limited to those accepting
+// submissions, or those looking for games like a particular one.
+func (s *PublisherService) ListPublishers(req *ListPublishersRequest) ([]domain.Publisher, error) {
+	publishers, err := s.PublisherRepository.ListPublishers(req.Accepting)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if req.GameID == 0 {
+		return publishers, nil
+	}
+
+	g, err := s.GameRepository.GameOfID(req.GameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, domain.GameNotFound{ProvidedID: req.GameID}
+	}
+
+	fits := []domain.Publisher{}
+	for _, p := range publishers {
+		if p.Criteria.Fits(g.Stats, g.Mechanics) {
+			fits = append(fits, p)
+		}
+	}
+
+	return fits, nil
+}
+
+// CreatePublisher creates a new publisher, with its owner as the first member. Only admins may do so, since
+// anyone else could claim a publisher's name.
+func (s *PublisherService) CreatePublisher(req *CreatePublisherRequest, userID uint) (*domain.Publisher, error) {
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if user == nil || !user.Admin {
+		return nil, domain.Forbidden{Action: "create publishers"}
+	}
+
+	owner := user
+	if req.OwnerID != 0 && req.OwnerID != user.ID {
+		owner, err = s.UserRepository.UserOfID(req.OwnerID)
+		if err != nil {
+			s.Logger.Error(err.Error())
+			return nil, err
+		}
+
+		if owner == nil {
+			return nil, domain.UserNotFound{ProvidedID: req.OwnerID}
+		}
+	}
+
+	p := domain.NewPublisher(req.Name, req.Website, *owner)
+
+	// And save
+	err = s.PublisherRepository.Save(p)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// GetPublisher returns a specific publisher
+func (s *PublisherService) GetPublisher(publisherID uint) (*domain.Publisher, error) {
+	return s.publisherOfID(publisherID)
+}
+
+// UpdatePublisher updates a publisher and what it's looking for. Only members may do so.
+func (s *PublisherService) UpdatePublisher(publisherID uint, req *UpdatePublisherRequest, userID uint) (*domain.Publisher, error) {
+	p, _, err := s.editablePublisher(publisherID, userID, "edit this publisher")
+	if err != nil {
+		return nil, err
+	}
+
+	p.Rename(req.Name)
+
+	if req.Website != "" {
+		p.UpdateWebsite(req.Website)
+	}
+
+	if req.Criteria != nil {
+		taxonomy, err := s.MechanicRepository.AllMechanics()
+		if err != nil {
+			s.Logger.Error(err.Error())
+			return nil, err
+		}
+
+		mechanics, err := domain.ResolveMechanics(taxonomy, req.Criteria.Mechanics)
+		if err != nil {
+			return nil, err
+		}
+
+		p.UpdateCriteria(publisher.Criteria{
+			AcceptingSubmissions: req.Criteria.AcceptingSubmissions,
+			Mechanics:            mechanics,
+			MinPlayers:           req.Criteria.MinPlayers,
+			MaxPlayers:           req.Criteria.MaxPlayers,
+			MaxPlaytime:          req.Criteria.MaxPlaytime,
+			Notes:                req.Criteria.Notes,
+		})
+	}
+
+	// And save
+	err = s.PublisherRepository.Save(p)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// AddMember adds someone to a publisher. Only the owner may do so.
+func (s *PublisherService) AddMember(publisherID uint, req *AddMemberRequest, userID uint) (*domain.Publisher, error) {
+	p, user, err := s.editablePublisher(publisherID, userID, "add members to this publisher")
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.UserRepository.UserOfID(req.UserID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if member == nil {
+		return nil, domain.UserNotFound{ProvidedID: req.UserID}
+	}
+
+	if err := p.AddMember(member, user); err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.PublisherRepository.SaveMembers(p)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// RemoveMember takes someone off a publisher. Members may leave on their own, while only the owner may
+// remove others.
+func (s *PublisherService) RemoveMember(publisherID, memberID, userID uint) (*domain.Publisher, error) {
+	p, user, err := s.editablePublisher(publisherID, userID, "remove members from this publisher")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.RemoveMember(memberID, user); err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.PublisherRepository.SaveMembers(p)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SubmitGame pitches a game to a publisher along with one of its sell sheets. Only the game's designers may
+// do so.
+func (s *PublisherService) SubmitGame(publisherID uint, req *SubmitGameRequest, userID uint) (*domain.Submission, error) {
+	p, err := s.publisherOfID(publisherID)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := s.GameRepository.GameOfID(req.GameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, domain.GameNotFound{ProvidedID: req.GameID}
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if !g.MayBeUpdatedBy(user) {
+		return nil, domain.Forbidden{Action: "submit this game"}
+	}
+
+	sellSheet, err := s.FileRepository.FileOfID(req.SellSheetID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	existing, err := s.SubmissionRepository.SubmissionsOfGame(g.ID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	submission, err := domain.SubmitGame(g, p, sellSheet, req.Pitch, user, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.SubmissionRepository.Save(submission)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Let the publisher know
+	event := domain.SubmissionReceived(submission)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	return submission, nil
+}
+
+// ListSubmissions returns the games submitted to a publisher, oldest first. The results are paginated. Only
+// members may see them.
+func (s *PublisherService) ListSubmissions(publisherID uint, req *ListSubmissionsRequest, userID uint) ([]domain.Submission, int, error) {
+	if _, _, err := s.editablePublisher(publisherID, userID, "review submissions to this publisher"); err != nil {
+		return nil, 0, err
+	}
+
+	// Limit our limit
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	submissions, total, err := s.SubmissionRepository.SubmissionsOfPublisher(publisherID, req.Stage, req.Limit, req.Offset)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, 0, err
+	}
+
+	return submissions, total, nil
+}
+
+// GameSubmissions returns every submission of a game, newest first. Only the game's designers may see them,
+// and the publishers' notes are left out.
+func (s *PublisherService) GameSubmissions(gameID, userID uint) ([]domain.Submission, error) {
+	g, err := s.GameRepository.GameOfID(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, domain.GameNotFound{ProvidedID: gameID}
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if !g.MayBeUpdatedBy(user) {
+		return nil, domain.Forbidden{Action: "see the submissions of this game"}
+	}
+
+	submissions, err := s.SubmissionRepository.SubmissionsOfGame(gameID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+// GetSubmission returns a specific submission to either side of it. Only the publisher's members see its notes.
+func (s *PublisherService) GetSubmission(submissionID, userID uint) (*domain.Submission, error) {
+	submission, user, err := s.submissionOfID(submissionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !submission.MayBeViewedBy(user) {
+		return nil, domain.Forbidden{Action: "see this submission"}
+	}
+
+	if !submission.MayBeReviewedBy(user) {
+		submission.HideNotes()
+	}
+
+	return submission, nil
+}
+
+// AdvanceSubmission moves a submission through the publisher's review. Only the publisher's members may do so.
+// Accepting a submission signs its game with the publisher.
+func (s *PublisherService) AdvanceSubmission(submissionID uint, req *AdvanceSubmissionRequest, userID uint) (*domain.Submission, error) {
+	submission, user, err := s.submissionOfID(submissionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !submission.MayBeReviewedBy(user) {
+		return nil, domain.Forbidden{Action: "review this submission"}
+	}
+
+	signed, err := submission.Advance(publisher.Stage(req.Stage), publisher.Decision(req.Decision), user)
+	if err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.SubmissionRepository.SaveReview(submission, signed)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Let the designers know
+	event := domain.SubmissionUpdated(submission)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	if signed != nil {
+		event := domain.GameStatusChanged(&submission.Game, signed)
+		pubsub.Instance.Publish(event.Name, event.Data)
+	}
+
+	return submission, nil
+}
+
+// AddSubmissionNote keeps a private note about a submission. Only the publisher's members may do so.
+func (s *PublisherService) AddSubmissionNote(submissionID uint, req *AddSubmissionNoteRequest, userID uint) (*domain.SubmissionNote, error) {
+	submission, user, err := s.submissionOfID(submissionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !submission.MayBeReviewedBy(user) {
+		return nil, domain.Forbidden{Action: "review this submission"}
+	}
+
+	note := submission.AddNote(user, req.Body)
+
+	// And save
+	err = s.SubmissionRepository.SaveNote(note)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// WithdrawSubmission pulls a submission from review before the publisher decides on it. Only the game's
+// designers may do so.
+func (s *PublisherService) WithdrawSubmission(submissionID, userID uint) (*domain.Submission, error) {
+	submission, user, err := s.submissionOfID(submissionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !submission.Game.MayBeUpdatedBy(user) {
+		return nil, domain.Forbidden{Action: "withdraw this submission"}
+	}
+
+	if err := submission.Withdraw(); err != nil {
+		return nil, err
+	}
+
+	// And save
+	err = s.SubmissionRepository.Save(submission)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Let the publisher know
+	event := domain.SubmissionUpdated(submission)
+	pubsub.Instance.Publish(event.Name, event.Data)
+
+	submission.HideNotes()
+
+	return submission, nil
+}
+
+func (s *PublisherService) publisherOfID(id uint) (*domain.Publisher, error) {
+	p, err := s.PublisherRepository.PublisherOfID(id)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, domain.PublisherNotFound{ProvidedID: id}
+	}
+
+	return p, nil
+}
+
+// editablePublisher fetches a publisher, provided the user is one of its members
+func (s *PublisherService) editablePublisher(publisherID, userID uint, action string) (*domain.Publisher, *domain.User, error) {
+	p, err := s.publisherOfID(publisherID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if !p.MayBeUpdatedBy(user) {
+		return nil, nil, domain.Forbidden{Action: action}
+	}
+
+	return p, user, nil
+}
+
+// submissionOfID fetches a submission along with the current user, leaving permissions to the caller
+func (s *PublisherService) submissionOfID(submissionID, userID uint) (*domain.Submission, *domain.User, error) {
+	submission, err := s.SubmissionRepository.SubmissionOfID(submissionID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	if submission == nil {
+		return nil, nil, domain.SubmissionNotFound{ProvidedID: submissionID}
+	}
+
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	return submission, user, nil
+}
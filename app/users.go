@@ -2,14 +2,16 @@ package app
 
 import (
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
 	"go.uber.org/zap"
 )
 
 type (
 	// UserService handles general interactions with users
 	UserService struct {
-		UserRepository domain.UserRepository
-		Logger         *zap.Logger
+		PlaytestRepository domain.PlaytestRepository
+		UserRepository     domain.UserRepository
+		Logger             *zap.Logger
 	}
 
 	// Request DTOs
@@ -31,6 +33,11 @@ type (
 		Limit  int           `json:"limit" example:"100"`
 		Offset int           `json:"offset" example:"50"`
 	}
+
+	// ReliabilityResponse how reliably a user shows up for playtests
+	ReliabilityResponse struct {
+		Reliability playtest.Reliability `json:"reliability"`
+	}
 )
 
 // ListUsers returns all users matching the specified query. The results are paginated
@@ -58,3 +65,50 @@ func (s *UserService) ListUsers(req *ListUsersRequest) ([]domain.User, int, erro
 
 	return users, total, nil
 }
+
+// SetAdmin grants or revokes admin rights for the user with the given email. Admins curate shared data, like
+// the mechanics taxonomy and publishers.
+func (s *UserService) SetAdmin(email string, admin bool) (*domain.User, error) {
+	user, err := s.UserRepository.UserOfEmail(email)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.UserNotFound{ProvidedEmail: email}
+	}
+
+	user.SetAdmin(admin)
+
+	err = s.UserRepository.Save(user)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UserReliability works out how reliably a user shows up for the playtests they join
+func (s *UserService) UserReliability(userID uint) (*playtest.Reliability, error) {
+	user, err := s.UserRepository.UserOfID(userID)
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.UserNotFound{ProvidedID: userID}
+	}
+
+	records, err := s.PlaytestRepository.AttendanceOfUsers([]uint{user.ID})
+	if err != nil {
+		s.Logger.Error(err.Error())
+		return nil, err
+	}
+
+	reliability := domain.ReliabilityOf([]uint{user.ID}, records)[user.ID]
+
+	return &reliability, nil
+}
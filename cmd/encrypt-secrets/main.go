@@ -0,0 +1,20 @@
+// Command encrypt-secrets is a one-off backfill that encrypts TTS passwords stored as plaintext before they were
+// encrypted at rest. ENCRYPTION_KEY has to match the one the API runs with. It's safe to run more than once.
+package main
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure"
+	"go.uber.org/zap"
+)
+
+func main() {
+	container := &infrastructure.Container{}
+	logger := container.Logger()
+
+	changed, err := container.PlaytestService().EncryptStoredSecrets()
+	if err != nil {
+		logger.Fatal("failed to encrypt stored secrets", zap.Error(err), zap.Int("changed", changed))
+	}
+
+	logger.Info("encrypted stored secrets", zap.Int("changed", changed))
+}
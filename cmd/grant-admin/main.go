@@ -0,0 +1,30 @@
+// Command grant-admin grants or revokes admin rights for a user, identified by their email. Admins curate shared
+// data, like the mechanics taxonomy and publishers, so there's deliberately no endpoint for this.
+package main
+
+import (
+	"flag"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure"
+	"go.uber.org/zap"
+)
+
+func main() {
+	email := flag.String("email", "", "email of the user to change")
+	revoke := flag.Bool("revoke", false, "revoke admin rights instead of granting them")
+	flag.Parse()
+
+	container := &infrastructure.Container{}
+	logger := container.Logger()
+
+	if *email == "" {
+		logger.Fatal("an email is required")
+	}
+
+	user, err := container.UserService().SetAdmin(*email, !*revoke)
+	if err != nil {
+		logger.Fatal("failed to change admin rights", zap.Error(err), zap.String("email", *email))
+	}
+
+	logger.Info("changed admin rights", zap.Uint("user", user.ID), zap.Bool("admin", user.Admin))
+}
@@ -0,0 +1,20 @@
+// Command sanitize-content is a one-off backfill that runs game overviews and rules already stored through the
+// same HTML sanitizer now applied on write. It's safe to run more than once.
+package main
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure"
+	"go.uber.org/zap"
+)
+
+func main() {
+	container := &infrastructure.Container{}
+	logger := container.Logger()
+
+	changed, err := container.GameService().SanitizeStoredContent()
+	if err != nil {
+		logger.Fatal("failed to sanitize stored content", zap.Error(err), zap.Int("changed", changed))
+	}
+
+	logger.Info("sanitized stored content", zap.Int("changed", changed))
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+// AttendanceRecord notes whether a player showed up when a playtest started
+type AttendanceRecord struct {
+	ID        uint      `json:"-" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	PlaytestID uint                `json:"-" gorm:"index"`
+	User       User                `json:"user"`
+	UserID     uint                `json:"-" gorm:"index"`
+	Status     playtest.Attendance `json:"status" example:"Attended"`
+}
+
+// Attended checks if the given user was checked in when the playtest started. Playtests started before
+// attendance was tracked have no records, so every player counts as having attended.
+func (p *Playtest) Attended(user *User) bool {
+	if user == nil {
+		return false
+	}
+
+	if len(p.Attendance) == 0 {
+		return p.HasPlayer(user)
+	}
+
+	for _, record := range p.Attendance {
+		if record.UserID == user.ID {
+			return record.Status == playtest.Attended
+		}
+	}
+
+	return false
+}
+
+// checkIn records which players showed up. Players missing from attendees are marked as no-shows.
+// A nil list of attendees checks in every player.
+func (p *Playtest) checkIn(attendees []uint) {
+	present := map[uint]bool{}
+	for _, id := range attendees {
+		present[id] = true
+	}
+
+	p.Attendance = []AttendanceRecord{}
+	for _, player := range p.Players {
+		status := playtest.Attended
+		if attendees != nil && !present[player.ID] {
+			status = playtest.NoShow
+		}
+
+		p.Attendance = append(p.Attendance, AttendanceRecord{
+			PlaytestID: p.ID,
+			User:       player,
+			UserID:     player.ID,
+			Status:     status,
+		})
+	}
+}
+
+// ReliabilityOf works out how reliably each user shows up, based on their attendance history.
+// Every requested user gets a score, even without any history.
+func ReliabilityOf(userIDs []uint, records []AttendanceRecord) map[uint]playtest.Reliability {
+	reliability := map[uint]playtest.Reliability{}
+	for _, id := range userIDs {
+		reliability[id] = playtest.Reliability{UserID: id, Score: 1}
+	}
+
+	for _, record := range records {
+		r, ok := reliability[record.UserID]
+		if !ok {
+			continue
+		}
+
+		if record.Status == playtest.NoShow {
+			r.NoShows++
+		} else {
+			r.Attended++
+		}
+
+		r.Score = float64(r.Attended) / float64(r.Attended+r.NoShows)
+		reliability[record.UserID] = r
+	}
+
+	return reliability
+}
+
+// FlagUnreliablePlayers lists the players across the playtests whose reliability falls below the threshold
+func FlagUnreliablePlayers(playtests []Playtest, reliability map[uint]playtest.Reliability, threshold float64) []playtest.Reliability {
+	flagged := []playtest.Reliability{}
+	seen := map[uint]bool{}
+
+	for _, p := range playtests {
+		for _, player := range p.Players {
+			r, ok := reliability[player.ID]
+			if !ok || seen[player.ID] || !r.IsBelow(threshold) {
+				continue
+			}
+
+			seen[player.ID] = true
+			flagged = append(flagged, r)
+		}
+	}
+
+	return flagged
+}
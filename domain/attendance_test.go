@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+func TestCheckInAtStart(t *testing.T) {
+	p := &Playtest{
+		State:   playtest.Seated,
+		Game:    Game{Designers: []User{User{ID: 1}}},
+		Players: []User{User{ID: 2}, User{ID: 3}},
+	}
+
+	if err := p.Start([]uint{2}); err != nil {
+		t.Fatalf("Unexpected error starting playtest: %s", err)
+	}
+
+	if len(p.Attendance) != 2 {
+		t.Fatalf("Expected attendance for 2 players, got %d", len(p.Attendance))
+	}
+
+	if !p.Attended(&User{ID: 2}) || p.Attended(&User{ID: 3}) {
+		t.Error("Only checked in players should be marked as attending")
+	}
+
+	p.State = playtest.Feedback
+	p.Finish()
+	if earned := EarnCredits(p); len(earned) != 1 || earned[0].UserID != 2 {
+		t.Error("No-shows shouldn't earn credits")
+	}
+
+	everyone := &Playtest{State: playtest.Seated, Players: []User{User{ID: 2}, User{ID: 3}}}
+	everyone.Start(nil)
+	if !everyone.Attended(&User{ID: 2}) || !everyone.Attended(&User{ID: 3}) {
+		t.Error("Starting without a list of attendees should check everyone in")
+	}
+}
+
+func TestReliabilityOf(t *testing.T) {
+	records := []AttendanceRecord{
+		{UserID: 1, Status: playtest.Attended},
+		{UserID: 1, Status: playtest.Attended},
+		{UserID: 1, Status: playtest.Attended},
+		{UserID: 1, Status: playtest.NoShow},
+		{UserID: 2, Status: playtest.NoShow},
+		{UserID: 2, Status: playtest.Attended},
+	}
+
+	reliability := ReliabilityOf([]uint{1, 2, 3}, records)
+
+	if r := reliability[1]; r.Attended != 3 || r.NoShows != 1 || r.Score != 0.75 {
+		t.Errorf("Reliability incorrect for user 1: %+v", r)
+	}
+
+	if r := reliability[3]; r.Score != 1 || r.IsBelow(playtest.LowReliability) {
+		t.Error("Users without history shouldn't be flagged")
+	}
+
+	playtests := []Playtest{
+		{Players: []User{User{ID: 1}, User{ID: 2}}},
+		{Players: []User{User{ID: 2}, User{ID: 3}}},
+	}
+
+	flagged := FlagUnreliablePlayers(playtests, reliability, playtest.LowReliability)
+	if len(flagged) != 1 || flagged[0].UserID != 2 {
+		t.Errorf("Expected only user 2 to be flagged, got %+v", flagged)
+	}
+}
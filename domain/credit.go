@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/credit"
+)
+
+// CreditTransaction is a single entry in a user's credit ledger. Credits are earned by playing in other
+// designers' playtests and spent registering games at events that charge for it.
+type CreditTransaction struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	UserID     uint          `json:"-" gorm:"index"`
+	PlaytestID *uint         `json:"playtest,omitempty" example:"123"`
+	Amount     int           `json:"amount" example:"1"`
+	Reason     credit.Reason `json:"reason" example:"Played"`
+}
+
+// CreditRepository defines how to interact with the credit ledger in database
+type CreditRepository interface {
+	BalanceOf(userID uint) (int, error)
+	TransactionsOf(userID uint, limit, offset int) ([]CreditTransaction, int, error)
+	TransactionsOfPlaytest(playtestID uint) ([]CreditTransaction, error)
+	Save(*CreditTransaction) error
+}
+
+// EarnCredits rewards every player who showed up for a finished playtest, except for the game's own designers
+func EarnCredits(p *Playtest) []CreditTransaction {
+	earned := []CreditTransaction{}
+	for _, player := range p.Players {
+		if p.Game.MayBeUpdatedBy(&player) || !p.Attended(&player) {
+			continue
+		}
+
+		earned = append(earned, CreditTransaction{
+			UserID:     player.ID,
+			PlaytestID: &p.ID,
+			Amount:     credit.PlaytestReward,
+			Reason:     credit.Played,
+		})
+	}
+
+	return earned
+}
+
+// SpendCredits charges the user for registering a playtest at an event. Free events don't need a transaction,
+// in which case nil is returned.
+func SpendCredits(u *User, balance int, e *Event) (*CreditTransaction, error) {
+	if e == nil || e.RegistrationCost == 0 {
+		return nil, nil
+	}
+
+	if balance < int(e.RegistrationCost) {
+		return nil, credit.InsufficientCredits{Balance: balance, Cost: e.RegistrationCost}
+	}
+
+	return &CreditTransaction{
+		UserID: u.ID,
+		Amount: -int(e.RegistrationCost),
+		Reason: credit.Registered,
+	}, nil
+}
+
+// RefundCredits returns whatever was spent registering a cancelled playtest. Anything already refunded is
+// taken into account, so refunding twice doesn't pay out twice.
+func RefundCredits(p *Playtest, transactions []CreditTransaction) []CreditTransaction {
+	owed := map[uint]int{}
+	users := []uint{}
+	for _, t := range transactions {
+		if t.PlaytestID == nil || *t.PlaytestID != p.ID {
+			continue
+		}
+
+		if t.Reason != credit.Registered && t.Reason != credit.Refunded {
+			continue
+		}
+
+		if _, seen := owed[t.UserID]; !seen {
+			users = append(users, t.UserID)
+		}
+
+		owed[t.UserID] -= t.Amount
+	}
+
+	refunds := []CreditTransaction{}
+	for _, userID := range users {
+		if owed[userID] <= 0 {
+			continue
+		}
+
+		refunds = append(refunds, CreditTransaction{
+			UserID:     userID,
+			PlaytestID: &p.ID,
+			Amount:     owed[userID],
+			Reason:     credit.Refunded,
+		})
+	}
+
+	return refunds
+}
+
+// ForPlaytest ties the transaction to the playtest it was for
+func (t *CreditTransaction) ForPlaytest(p *Playtest) {
+	t.PlaytestID = &p.ID
+}
@@ -0,0 +1,13 @@
+package credit
+
+import "fmt"
+
+// InsufficientCredits error for when a user can't afford to register for a playtest
+type InsufficientCredits struct {
+	Balance int
+	Cost    uint
+}
+
+func (e InsufficientCredits) Error() string {
+	return fmt.Sprintf("registering costs %d credits but you only have %d. Play in other designers' tests to earn more", e.Cost, e.Balance)
+}
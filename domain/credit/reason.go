@@ -0,0 +1,18 @@
+package credit
+
+// Reason explains why credits changed hands
+type Reason string
+
+const (
+	// Played credits are earned by finishing as a player in someone else's playtest
+	Played Reason = "Played"
+
+	// Registered credits are spent registering a game for a playtest at an event
+	Registered = "Registered"
+
+	// Refunded credits are returned when a playtest they were spent on is cancelled
+	Refunded = "Refunded"
+)
+
+// PlaytestReward is how many credits a player earns for each playtest they finish
+const PlaytestReward = 1
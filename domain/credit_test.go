@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/credit"
+)
+
+func TestEarnCredits(t *testing.T) {
+	p := &Playtest{
+		ID:      1,
+		Game:    Game{Designers: []User{User{ID: 1}}},
+		Players: []User{User{ID: 1}, User{ID: 2}, User{ID: 3}},
+	}
+
+	earned := EarnCredits(p)
+	if len(earned) != 2 {
+		t.Fatalf("Expected 2 players to earn credits, got %d", len(earned))
+	}
+
+	for _, tx := range earned {
+		if tx.UserID == 1 {
+			t.Error("Designers shouldn't earn credits for playing their own game")
+		}
+
+		if tx.Amount != credit.PlaytestReward || tx.Reason != credit.Played || *tx.PlaytestID != 1 {
+			t.Errorf("Earned transaction incorrect: %+v", tx)
+		}
+	}
+}
+
+func TestSpendCredits(t *testing.T) {
+	u := &User{ID: 1}
+
+	var tests = []struct {
+		event          *Event
+		balance        int
+		expectedAmount int
+		expectError    bool
+	}{
+		{nil, 0, 0, false},
+		{&Event{}, 0, 0, false},
+		{&Event{RegistrationCost: 2}, 3, -2, false},
+		{&Event{RegistrationCost: 2}, 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		tx, err := SpendCredits(u, tt.balance, tt.event)
+		if tt.expectError {
+			if _, ok := err.(credit.InsufficientCredits); !ok {
+				t.Errorf("Expected InsufficientCredits error, got '%v'", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error spending credits: %s", err)
+			continue
+		}
+
+		if tt.expectedAmount == 0 && tx != nil {
+			t.Error("Free events shouldn't charge credits")
+		}
+
+		if tt.expectedAmount != 0 && (tx == nil || tx.Amount != tt.expectedAmount || tx.Reason != credit.Registered) {
+			t.Errorf("Spent transaction incorrect: %+v", tx)
+		}
+	}
+}
+
+func TestRefundCredits(t *testing.T) {
+	playtestID := uint(1)
+	otherID := uint(2)
+	p := &Playtest{ID: playtestID}
+
+	transactions := []CreditTransaction{
+		{UserID: 1, PlaytestID: &playtestID, Amount: -3, Reason: credit.Registered},
+		{UserID: 2, PlaytestID: &playtestID, Amount: 1, Reason: credit.Played},
+		{UserID: 1, PlaytestID: &otherID, Amount: -2, Reason: credit.Registered},
+	}
+
+	refunds := RefundCredits(p, transactions)
+	if len(refunds) != 1 {
+		t.Fatalf("Expected 1 refund, got %+v", refunds)
+	}
+
+	if refunds[0].UserID != 1 || refunds[0].Amount != 3 || refunds[0].Reason != credit.Refunded || *refunds[0].PlaytestID != playtestID {
+		t.Errorf("Refund incorrect: %+v", refunds[0])
+	}
+
+	if again := RefundCredits(p, append(transactions, refunds...)); len(again) != 0 {
+		t.Errorf("Credits shouldn't be refunded twice, got %+v", again)
+	}
+}
@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+// DesignerInvitation asks someone to co-design a game. Invitations are sent to existing users, or to an email
+// address for people who haven't signed up yet, and nobody becomes a designer until they accept.
+type DesignerInvitation struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+	UpdatedAt time.Time `json:"updated_at" example:"2020-12-13T15:42:40.578904-08:00"`
+
+	Game        Game   `json:"game"`
+	GameID      uint   `json:"-" gorm:"index"`
+	InvitedBy   User   `json:"invited_by"`
+	InvitedByID uint   `json:"-"`
+	Invitee     *User  `json:"invitee,omitempty"`
+	InviteeID   *uint  `json:"-" gorm:"index"`
+	Email       string `json:"email,omitempty" gorm:"index" example:"friend@example.com"` // Only for people without an account
+
+	Status      game.InvitationStatus `json:"status" example:"Pending"`
+	RespondedAt *time.Time            `json:"responded_at,omitempty" example:"2020-12-13T15:42:40.578904-08:00"`
+}
+
+// DesignerChange records a single change to who designs a game, along with who made it. Together, a game's
+// changes make up the full history of its designers.
+type DesignerChange struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	GameID      uint                `json:"-" gorm:"index"`
+	Action      game.DesignerAction `json:"action" example:"Invited"`
+	Designer    *User               `json:"designer,omitempty"`
+	DesignerID  *uint               `json:"-"`
+	Email       string              `json:"email,omitempty" example:"friend@example.com"`
+	ChangedBy   User                `json:"changed_by"`
+	ChangedByID uint                `json:"-"`
+}
+
+// DesignerRepository defines how to interact with game designers and their invitations in database
+type DesignerRepository interface {
+	InvitationsOfGame(gameID uint) ([]DesignerInvitation, error)
+	InvitationsForUser(user *User) ([]DesignerInvitation, error)
+	InvitationOfID(id uint) (*DesignerInvitation, error)
+	DesignerHistory(gameID uint) ([]DesignerChange, error)
+	SaveInvitation(*DesignerInvitation, *DesignerChange) error
+	SaveDesigners(*Game, *DesignerChange) error
+}
+
+// InviteDesigner asks a user, or someone by email, to co-design the game. People already designing the game,
+// or with an invitation waiting on an answer, can't be invited again.
+func (g *Game) InviteDesigner(invitee *User, email string, by *User, invitations []DesignerInvitation) (*DesignerInvitation, *DesignerChange, error) {
+	invitation := &DesignerInvitation{
+		Game:        *g,
+		GameID:      g.ID,
+		InvitedBy:   *by,
+		InvitedByID: by.ID,
+		Status:      game.InvitationPending,
+	}
+
+	if invitee != nil {
+		for _, d := range g.Designers {
+			if d.ID == invitee.ID {
+				return nil, nil, game.AlreadyDesigner{Name: invitee.Name}
+			}
+		}
+
+		invitation.Invitee = invitee
+		invitation.InviteeID = &invitee.ID
+	} else {
+		invitation.Email = strings.ToLower(strings.TrimSpace(email))
+	}
+
+	for _, i := range invitations {
+		if i.Status != game.InvitationPending {
+			continue
+		}
+
+		if (invitee != nil && i.InviteeID != nil && *i.InviteeID == invitee.ID) || (i.Email != "" && i.Email == invitation.Email) {
+			return nil, nil, game.AlreadyInvited{}
+		}
+	}
+
+	return invitation, invitation.change(game.DesignerInvited, by), nil
+}
+
+// For checks if the invitation was sent to the given user, either directly or to their email. Invitations
+// sent to an email only belong to a user once they've verified that email.
+func (i *DesignerInvitation) For(user *User) bool {
+	if user == nil {
+		return false
+	}
+
+	if i.InviteeID != nil {
+		return *i.InviteeID == user.ID
+	}
+
+	return i.Email != "" && user.Account.Verified && strings.EqualFold(i.Email, user.Account.Email)
+}
+
+// Accept makes the invitee a designer of the game
+func (i *DesignerInvitation) Accept(user *User) (*DesignerChange, error) {
+	if err := i.answer(user, game.InvitationAccepted); err != nil {
+		return nil, err
+	}
+
+	i.Game.AddDesigner(user)
+
+	return i.change(game.DesignerJoined, user), nil
+}
+
+// Decline turns down the invitation
+func (i *DesignerInvitation) Decline(user *User) (*DesignerChange, error) {
+	if err := i.answer(user, game.InvitationDeclined); err != nil {
+		return nil, err
+	}
+
+	return i.change(game.DesignerDeclined, user), nil
+}
+
+// Cancel withdraws an invitation that hasn't been answered yet. Any of the game's designers may do so.
+func (i *DesignerInvitation) Cancel(by *User) (*DesignerChange, error) {
+	if !i.Game.MayBeUpdatedBy(by) {
+		return nil, Forbidden{Action: "cancel this invitation"}
+	}
+
+	if i.Status != game.InvitationPending {
+		return nil, game.InvitationClosed{Status: i.Status}
+	}
+
+	i.Status = game.InvitationCancelled
+
+	return i.change(game.InvitationWithdrawn, by), nil
+}
+
+// answer records the invitee's response, provided the invitation is theirs and still open
+func (i *DesignerInvitation) answer(user *User, status game.InvitationStatus) error {
+	if !i.For(user) {
+		return Forbidden{Action: "answer this invitation"}
+	}
+
+	if i.Status != game.InvitationPending {
+		return game.InvitationClosed{Status: i.Status}
+	}
+
+	now := time.Now()
+	i.Status = status
+	i.RespondedAt = &now
+	i.Invitee = user
+	i.InviteeID = &user.ID
+
+	return nil
+}
+
+// change describes something that happened to the invitation, for the game's designer history
+func (i *DesignerInvitation) change(action game.DesignerAction, by *User) *DesignerChange {
+	return &DesignerChange{
+		GameID:      i.GameID,
+		Action:      action,
+		Designer:    i.Invitee,
+		DesignerID:  i.InviteeID,
+		Email:       i.Email,
+		ChangedBy:   *by,
+		ChangedByID: by.ID,
+	}
+}
+
+// RemoveDesigner takes a designer off the game. Designers may leave on their own, while only the owner may
+// remove others. The owner has to transfer ownership before leaving, and the last designer can't be removed.
+func (g *Game) RemoveDesigner(designerID uint, by *User) (*DesignerChange, error) {
+	index := -1
+	for i, d := range g.Designers {
+		if d.ID == designerID {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		return nil, game.NotADesigner{ProvidedID: designerID}
+	}
+
+	action := game.DesignerLeft
+	if designerID != by.ID {
+		if by.ID != g.OwnerID {
+			return nil, Forbidden{Action: "remove designers from this game"}
+		}
+
+		action = game.DesignerRemoved
+	}
+
+	if len(g.Designers) == 1 {
+		return nil, game.LastDesigner{}
+	}
+
+	if designerID == g.OwnerID {
+		return nil, game.OwnerRemoval{}
+	}
+
+	designer := g.Designers[index]
+	g.Designers = append(g.Designers[:index], g.Designers[index+1:]...)
+
+	return &DesignerChange{
+		GameID:      g.ID,
+		Action:      action,
+		Designer:    &designer,
+		DesignerID:  &designer.ID,
+		ChangedBy:   *by,
+		ChangedByID: by.ID,
+	}, nil
+}
+
+// TransferOwnership hands the game to another of its designers. Only the owner may do so.
+func (g *Game) TransferOwnership(designerID uint, by *User) (*DesignerChange, error) {
+	if by.ID != g.OwnerID {
+		return nil, Forbidden{Action: "transfer ownership of this game"}
+	}
+
+	for _, d := range g.Designers {
+		if d.ID == designerID {
+			designer := d
+			g.OwnerID = designer.ID
+
+			return &DesignerChange{
+				GameID:      g.ID,
+				Action:      game.OwnershipTransferred,
+				Designer:    &designer,
+				DesignerID:  &designer.ID,
+				ChangedBy:   *by,
+				ChangedByID: by.ID,
+			}, nil
+		}
+	}
+
+	return nil, game.NotADesigner{ProvidedID: designerID}
+}
+
+// DesignerInvited lets the invitee know they were asked to co-design the game
+func DesignerInvited(i *DesignerInvitation) DomainEvent {
+	name, address := "", i.Email
+	if i.Invitee != nil {
+		name, address = i.Invitee.Name, i.Invitee.Account.Email
+	}
+
+	return DomainEvent{
+		Name: "Game/DesignerInvited",
+		Data: map[string]interface{}{
+			"gameID":    i.GameID,
+			"game":      i.Game.Title,
+			"invitedBy": i.InvitedBy.Name,
+			"name":      name,
+			"email":     address,
+		},
+	}
+}
+
+// InvitationAnswered lets the game's other designers know the invitee accepted or declined
+func InvitationAnswered(i *DesignerInvitation) DomainEvent {
+	return DomainEvent{
+		Name: "Game/InvitationAnswered",
+		Data: map[string]interface{}{
+			"gameID":    i.GameID,
+			"game":      i.Game.Title,
+			"invitee":   i.Invitee.Name,
+			"status":    string(i.Status),
+			"designers": i.Game.recipients(*i.InviteeID),
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/user"
+)
+
+func TestInviteDesigner(t *testing.T) {
+	owner := &User{ID: 1}
+	invitee := uint(3)
+	pending := []DesignerInvitation{{InviteeID: &invitee, Email: "friend@example.com", Status: game.InvitationPending}}
+
+	var tests = []struct {
+		game          *Game
+		invitee       *User
+		email         string
+		existing      []DesignerInvitation
+		expectedEmail string
+		expectedError bool
+	}{
+		{&Game{ID: 1, OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, &User{ID: 3}, "", nil, "", false},
+		{&Game{ID: 1, OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, &User{ID: 3}, "", pending, "", true}, // Already invited
+		{&Game{ID: 1, OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, &User{ID: 2}, "", nil, "", true},     // Already a designer
+		{&Game{ID: 1, OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, nil, " Friend@Example.com ", nil, "friend@example.com", false},
+		{&Game{ID: 1, OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, nil, "friend@example.com", pending, "", true}, // Already invited by email
+	}
+
+	for i, tt := range tests {
+		invitation, change, err := tt.game.InviteDesigner(tt.invitee, tt.email, owner, tt.existing)
+		if tt.expectedError {
+			if err == nil {
+				t.Errorf("Case %d: expected the invitation to be rejected", i)
+			}
+
+			continue
+		}
+
+		if err != nil || invitation.Status != game.InvitationPending || change.Action != game.DesignerInvited || invitation.Email != tt.expectedEmail {
+			t.Errorf("Case %d: invitation incorrect: %+v %+v (%v)", i, invitation, change, err)
+			continue
+		}
+
+		if (tt.invitee == nil) != (invitation.InviteeID == nil) {
+			t.Errorf("Case %d: expected invitee %+v, got %v", i, tt.invitee, invitation.InviteeID)
+		}
+
+		if len(tt.game.Designers) != 2 {
+			t.Errorf("Case %d: expected invitee not to be a designer until they accept", i)
+		}
+	}
+}
+
+func TestAnswerInvitation(t *testing.T) {
+	g := &Game{ID: 1, Title: "Snail Race", OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}
+	invitation, _, _ := g.InviteDesigner(nil, "friend@example.com", &User{ID: 1}, nil)
+
+	if _, err := invitation.Accept(&User{ID: 4, Account: user.Account{Email: "stranger@example.com"}}); err == nil {
+		t.Error("Expected strangers to be unable to accept")
+	}
+
+	friend := &User{ID: 3, Account: user.Account{Email: "FRIEND@example.com"}}
+	if _, err := invitation.Accept(friend); err == nil {
+		t.Error("Expected unverified emails to be unable to accept")
+	}
+
+	friend.Account.Verified = true
+	change, err := invitation.Accept(friend)
+	if err != nil {
+		t.Fatalf("Expected invitation to be accepted, got %v", err)
+	}
+
+	if invitation.Status != game.InvitationAccepted || *invitation.InviteeID != 3 || change.Action != game.DesignerJoined {
+		t.Errorf("Acceptance incorrect: %+v %+v", invitation, change)
+	}
+
+	if !invitation.Game.MayBeUpdatedBy(friend) {
+		t.Error("Expected invitee to become a designer")
+	}
+
+	if _, err := invitation.Decline(friend); err == nil {
+		t.Error("Expected answered invitations to stay answered")
+	}
+
+	if _, err := invitation.Cancel(&User{ID: 1}); err == nil {
+		t.Error("Expected answered invitations to be impossible to cancel")
+	}
+}
+
+func TestRemoveDesigner(t *testing.T) {
+	var tests = []struct {
+		game           *Game
+		designerID     uint
+		by             uint
+		expectedAction game.DesignerAction
+		expectedError  bool
+	}{
+		{&Game{OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, 2, 1, game.DesignerRemoved, false},
+		{&Game{OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, 2, 2, game.DesignerLeft, false},
+		{&Game{OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, 1, 2, "", true}, // Only the owner removes others
+		{&Game{OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, 1, 1, "", true}, // The owner has to transfer first
+		{&Game{OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}, 3, 1, "", true}, // Not a designer
+		{&Game{OwnerID: 1, Designers: []User{{ID: 1}}}, 1, 1, "", true},          // The last designer stays
+	}
+
+	for _, tt := range tests {
+		g := tt.game
+
+		change, err := g.RemoveDesigner(tt.designerID, &User{ID: tt.by})
+		if tt.expectedError {
+			if err == nil {
+				t.Errorf("Expected removing %d by %d to fail", tt.designerID, tt.by)
+			}
+			continue
+		}
+
+		if err != nil || change.Action != tt.expectedAction || len(g.Designers) != 1 {
+			t.Errorf("Expected %s removing %d by %d, got %+v (%v)", tt.expectedAction, tt.designerID, tt.by, change, err)
+		}
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	g := &Game{ID: 1, OwnerID: 1, Designers: []User{{ID: 1}, {ID: 2}}}
+
+	if _, err := g.TransferOwnership(2, &User{ID: 2}); err == nil {
+		t.Error("Expected only the owner to transfer ownership")
+	}
+
+	if _, err := g.TransferOwnership(3, &User{ID: 1}); err == nil {
+		t.Error("Expected ownership to stay with designers")
+	}
+
+	change, err := g.TransferOwnership(2, &User{ID: 1})
+	if err != nil || g.OwnerID != 2 || change.Action != game.OwnershipTransferred {
+		t.Errorf("Expected ownership to transfer, got %+v (%v)", change, err)
+	}
+
+	if _, err := g.RemoveDesigner(1, &User{ID: 1}); err != nil {
+		t.Errorf("Expected previous owner to be able to leave, got %v", err)
+	}
+}
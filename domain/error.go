@@ -46,3 +46,91 @@ type Unauthorized struct{}
 func (e Unauthorized) Error() string {
 	return "you are not allowed to do that"
 }
+
+// Forbidden error for authenticated users who lack permission for an action
+type Forbidden struct {
+	Action string
+}
+
+func (e Forbidden) Error() string {
+	if e.Action != "" {
+		return fmt.Sprintf("you are not allowed to %s", e.Action)
+	}
+
+	return "you are not allowed to do that"
+}
+
+// PlaytestNotFound error
+type PlaytestNotFound struct {
+	ProvidedID uint
+}
+
+func (e PlaytestNotFound) Error() string {
+	if e.ProvidedID != 0 {
+		return fmt.Sprintf("playtest '%d' not found", e.ProvidedID)
+	}
+
+	return "playtest not found"
+}
+
+// MechanicNotFound error
+type MechanicNotFound struct {
+	ProvidedID uint
+}
+
+func (e MechanicNotFound) Error() string {
+	return fmt.Sprintf("mechanic '%d' not found", e.ProvidedID)
+}
+
+// EventNotFound error
+type EventNotFound struct {
+	ProvidedID uint
+}
+
+func (e EventNotFound) Error() string {
+	if e.ProvidedID != 0 {
+		return fmt.Sprintf("event '%d' not found", e.ProvidedID)
+	}
+
+	return "event not found"
+}
+
+// GameNotFound error
+type GameNotFound struct {
+	ProvidedID uint
+}
+
+func (e GameNotFound) Error() string {
+	if e.ProvidedID != 0 {
+		return fmt.Sprintf("game '%d' not found", e.ProvidedID)
+	}
+
+	return "game not found"
+}
+
+// PublisherNotFound error
+type PublisherNotFound struct {
+	ProvidedID uint
+}
+
+func (e PublisherNotFound) Error() string {
+	return fmt.Sprintf("publisher '%d' not found", e.ProvidedID)
+}
+
+// SubmissionNotFound error
+type SubmissionNotFound struct {
+	ProvidedID uint
+}
+
+func (e SubmissionNotFound) Error() string {
+	return fmt.Sprintf("submission '%d' not found", e.ProvidedID)
+}
+
+// InvitationNotFound error
+type InvitationNotFound struct {
+	ProvidedID uint
+}
+
+func (e InvitationNotFound) Error() string {
+	return fmt.Sprintf("invitation '%d' not found", e.ProvidedID)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/event"
+	"github.com/teambition/rrule-go"
 	"gorm.io/gorm"
 )
 
@@ -22,8 +23,11 @@ type Event struct {
 	Location     string     `json:"location,omitempty" example:"123 Fake St..."`
 	URL          string     `json:"url,omitempty" example:"https://discord.gg/ABC1234"`
 
-	Duration time.Duration `json:"duration" example:"14400000"`
-	RRule    string        `json:"rrule"`
+	Duration   time.Duration `json:"duration" example:"14400000"`
+	SlotLength time.Duration `json:"slot_length" example:"7200000"`
+	RRule      string        `json:"rrule"`
+
+	RegistrationCost uint `json:"registration_cost" example:"2"` // Credits to register a game. Free if 0
 }
 
 // EventRepository defines how to interact with events in database
@@ -75,6 +79,109 @@ func (e *Event) MayBeUpdatedBy(user *User) bool {
 	return false
 }
 
+// OccurrencesOn returns the start of every occurrence of the event on the given day. Days are measured
+// in the event's own time zone.
+func (e *Event) OccurrencesOn(date time.Time) ([]time.Time, error) {
+	set, err := e.recurrence()
+	if err != nil {
+		return nil, err
+	}
+
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, set.GetDTStart().Location())
+
+	return set.Between(day, day.AddDate(0, 0, 1).Add(-time.Second), true), nil
+}
+
+// TimeZone the event is scheduled in. Without an event, or a schedule that can be read, days are measured in UTC.
+func (e *Event) TimeZone() *time.Location {
+	if e == nil {
+		return time.UTC
+	}
+
+	set, err := e.recurrence()
+	if err != nil || set.GetDTStart().Location() == nil {
+		return time.UTC
+	}
+
+	return set.GetDTStart().Location()
+}
+
+// Day returns the start of the calendar day the date falls on, in the event's time zone, and the start of
+// the next one, so playtests on that day are scheduled from start up to, but not including, end.
+func (e *Event) Day(date time.Time) (start, end time.Time) {
+	y, m, d := date.Date()
+	start = time.Date(y, m, d, 0, 0, 0, 0, e.TimeZone())
+
+	return start, start.AddDate(0, 0, 1)
+}
+
+// HoursOn returns when the event opens on the given day, at the start of its first occurrence, and when it
+// closes, at the end of its last one
+func (e *Event) HoursOn(date time.Time) (opens, closes time.Time, err error) {
+	occurrences, err := e.OccurrencesOn(date)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if len(occurrences) == 0 {
+		return time.Time{}, time.Time{}, event.NotScheduled{Date: date}
+	}
+
+	return occurrences[0], occurrences[len(occurrences)-1].Add(e.Duration), nil
+}
+
+// SlotsOn splits every occurrence of the event on the given day into slots of SlotLength. Events
+// without a slot length get a single slot covering each occurrence.
+func (e *Event) SlotsOn(date time.Time) ([]event.Slot, error) {
+	occurrences, err := e.OccurrencesOn(date)
+	if err != nil {
+		return nil, err
+	}
+
+	length := e.SlotLength
+	if length <= 0 || length > e.Duration {
+		length = e.Duration
+	}
+
+	slots := []event.Slot{}
+	for _, start := range occurrences {
+		end := start.Add(e.Duration)
+
+		// Zero length events still get a single slot so they can be registered for
+		if length <= 0 {
+			slots = append(slots, event.Slot{Number: uint(len(slots) + 1), StartsAt: start, EndsAt: end})
+			continue
+		}
+
+		for s := start; !s.Add(length).After(end); s = s.Add(length) {
+			slots = append(slots, event.Slot{Number: uint(len(slots) + 1), StartsAt: s, EndsAt: s.Add(length)})
+		}
+	}
+
+	return slots, nil
+}
+
+// SlotOn finds a particular slot of the event on the given day
+func (e *Event) SlotOn(date time.Time, number uint) (*event.Slot, error) {
+	slots, err := e.SlotsOn(date)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(slots) == 0 {
+		return nil, event.NotScheduled{Date: date}
+	}
+
+	for _, slot := range slots {
+		if slot.Number == number {
+			return &slot, nil
+		}
+	}
+
+	return nil, event.InvalidSlot{ProvidedSlot: number, Date: date}
+}
+
 // Rename will change the title of the event. Blank names are not allowed.
 func (e *Event) Rename(newTitle string) {
 	if newTitle != "" && e.Title != newTitle {
@@ -150,7 +257,35 @@ func (e *Event) UpdateDuration(newDuration int64) {
 	e.Duration = time.Duration(newDuration)
 }
 
+// UpdateSlotLength replaces the existing SlotLength
+func (e *Event) UpdateSlotLength(newSlotLength int64) {
+	e.SlotLength = time.Duration(newSlotLength)
+}
+
+// UpdateRegistrationCost replaces the number of credits it takes to register a game
+func (e *Event) UpdateRegistrationCost(cost uint) {
+	e.RegistrationCost = cost
+}
+
 // UpdateRRule replaces the existing RRule
 func (e *Event) UpdateRRule(newRRule string) {
 	e.RRule = newRRule
 }
+
+// recurrence parses the event's RRule. Both full recurrence sets (with DTSTART) and bare rules are accepted.
+func (e *Event) recurrence() (*rrule.Set, error) {
+	set, err := rrule.StrToRRuleSet(e.RRule)
+	if err == nil {
+		return set, nil
+	}
+
+	rule, err := rrule.StrToRRule(e.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	set = &rrule.Set{}
+	set.RRule(rule)
+
+	return set, nil
+}
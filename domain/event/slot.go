@@ -0,0 +1,38 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// Slot is a block of time within an event occurrence that a playtest can be scheduled into.
+// Slots are numbered from 1 across the whole day.
+type Slot struct {
+	Number   uint      `json:"number" example:"1"`
+	StartsAt time.Time `json:"starts_at" example:"2020-12-16T18:00:00-08:00"`
+	EndsAt   time.Time `json:"ends_at" example:"2020-12-16T20:00:00-08:00"`
+}
+
+// Contains checks if the given time falls within the slot
+func (s Slot) Contains(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// NotScheduled returned when the event doesn't occur on the requested date
+type NotScheduled struct {
+	Date time.Time
+}
+
+func (e NotScheduled) Error() string {
+	return fmt.Sprintf("event does not occur on %s", e.Date.Format("2006-01-02"))
+}
+
+// InvalidSlot returned when the requested slot isn't part of the event's occurrences on a date
+type InvalidSlot struct {
+	ProvidedSlot uint
+	Date         time.Time
+}
+
+func (e InvalidSlot) Error() string {
+	return fmt.Sprintf("slot %d is not available on %s", e.ProvidedSlot, e.Date.Format("2006-01-02"))
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/event"
+)
+
+func TestEventSlotsOn(t *testing.T) {
+	// Wednesdays, 6pm to 10pm, split into two hour rounds
+	e := &Event{
+		Duration:   4 * time.Hour,
+		SlotLength: 2 * time.Hour,
+		RRule:      "DTSTART:20201202T180000Z\nRRULE:FREQ=WEEKLY;BYDAY=WE",
+	}
+
+	wednesday := time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC)
+	slots, err := e.SlotsOn(wednesday)
+	if err != nil {
+		t.Fatalf("Unexpected error listing slots: %s", err)
+	}
+
+	if len(slots) != 2 {
+		t.Fatalf("Expected 2 slots, got %d", len(slots))
+	}
+
+	if slots[0].Number != 1 || !slots[0].StartsAt.Equal(time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("First slot incorrect: %+v", slots[0])
+	}
+
+	if slots[1].Number != 2 || !slots[1].StartsAt.Equal(time.Date(2020, 12, 16, 20, 0, 0, 0, time.UTC)) || !slots[1].EndsAt.Equal(time.Date(2020, 12, 16, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("Second slot incorrect: %+v", slots[1])
+	}
+
+	thursday := wednesday.AddDate(0, 0, 1)
+	if _, err := e.SlotOn(thursday, 1); err == nil {
+		t.Error("Slots shouldn't be available on days without an occurrence")
+	} else if _, ok := err.(event.NotScheduled); !ok {
+		t.Errorf("Expected NotScheduled error, got '%v'", err)
+	}
+
+	if _, err := e.SlotOn(wednesday, 3); err == nil {
+		t.Error("Slots outside the occurrence shouldn't be available")
+	} else if _, ok := err.(event.InvalidSlot); !ok {
+		t.Errorf("Expected InvalidSlot error, got '%v'", err)
+	}
+
+	// Without a slot length, the whole occurrence is a single slot
+	e.SlotLength = 0
+	slots, _ = e.SlotsOn(wednesday)
+	if len(slots) != 1 || !slots[0].EndsAt.Equal(time.Date(2020, 12, 16, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected a single slot covering the occurrence, got %+v", slots)
+	}
+}
+
+func TestEventDay(t *testing.T) {
+	e := &Event{RRule: "DTSTART;TZID=America/Los_Angeles:20201202T180000\nRRULE:FREQ=WEEKLY;BYDAY=WE"}
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("Time zone data unavailable")
+	}
+
+	start, end := e.Day(time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2020, 12, 16, 0, 0, 0, 0, pacific)) || !end.Equal(time.Date(2020, 12, 17, 0, 0, 0, 0, pacific)) {
+		t.Errorf("Expected the day in the event's time zone, got %s to %s", start, end)
+	}
+
+	var none *Event
+	start, end = none.Day(time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC)) || end.Sub(start) != 24*time.Hour {
+		t.Errorf("Expected days without an event to be in UTC, got %s to %s", start, end)
+	}
+}
+
+func TestRegisterGameInSlot(t *testing.T) {
+	e := &Event{
+		ID:         1,
+		Duration:   4 * time.Hour,
+		SlotLength: 2 * time.Hour,
+		RRule:      "DTSTART:20201202T180000Z\nRRULE:FREQ=WEEKLY;BYDAY=WE",
+	}
+	wednesday := time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	p, err := RegisterGame(&Game{ID: 1}, e, wednesday, 2, 2, 4, 60, true, "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error registering game: %s", err)
+	}
+
+	if p.Slot != 2 || !p.ScheduledDate.Equal(time.Date(2020, 12, 16, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("Playtest should start at its slot, got slot %d at %s", p.Slot, p.ScheduledDate)
+	}
+
+	if _, err := RegisterGame(&Game{ID: 1}, e, wednesday.AddDate(0, 0, 1), 1, 2, 4, 60, true, "", "", ""); err == nil {
+		t.Error("Registration should fail when the event doesn't occur on the date")
+	}
+
+	p, err = RegisterGame(&Game{ID: 1}, nil, wednesday.Add(5*time.Hour), 2, 2, 4, 60, true, "", "", "")
+	if err != nil || p.EventID != nil || p.Slot != 0 || !p.ScheduledDate.Equal(wednesday) {
+		t.Error("Playtests without an event should only be scheduled for a date")
+	}
+}
+
+func TestGroupPlaytestsBySlot(t *testing.T) {
+	eventID := uint(1)
+	six := time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)
+	eight := six.Add(2 * time.Hour)
+
+	slots := GroupPlaytestsBySlot([]Playtest{
+		{ID: 1, EventID: &eventID, Slot: 2, ScheduledDate: eight},
+		{ID: 2, EventID: &eventID, Slot: 1, ScheduledDate: six.Add(time.Hour)},
+		{ID: 3, EventID: &eventID, Slot: 1, ScheduledDate: six},
+	})
+
+	if len(slots) != 2 {
+		t.Fatalf("Expected 2 slots, got %d", len(slots))
+	}
+
+	if slots[0].Slot != 1 || !slots[0].StartsAt.Equal(six) || len(slots[0].Playtests) != 2 {
+		t.Errorf("First slot incorrect: %+v", slots[0])
+	}
+
+	if slots[1].Slot != 2 || len(slots[1].Playtests) != 1 {
+		t.Errorf("Second slot incorrect: %+v", slots[1])
+	}
+}
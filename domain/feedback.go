@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/feedback"
+	"gorm.io/gorm"
+)
+
+// Feedback is a single player's response to a playtest
+type Feedback struct {
+	ID        uint           `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time      `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+	UpdatedAt time.Time      `json:"updated_at" example:"2020-12-13T15:42:40.578904-08:00"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Playtest   *Playtest `json:"-"`
+	PlaytestID uint      `json:"-" gorm:"uniqueIndex:idx_feedback_playtest_player"`
+
+	Player   User `json:"player"`
+	PlayerID uint `json:"-" gorm:"uniqueIndex:idx_feedback_playtest_player"`
+
+	Ratings            feedback.Ratings `json:"ratings" gorm:"embedded;embeddedPrefix:rating_"`
+	Comments           string           `json:"comments" example:"Loved the theme! The end game dragged a bit."`
+	HopingToTestAnswer string           `json:"hoping_to_test_answer" example:"Yes, after the first round"`
+}
+
+// FeedbackRepository defines how to interact with feedback in database
+type FeedbackRepository interface {
+	FeedbackOfPlaytest(playtestID uint) ([]Feedback, error)
+	FeedbackOfPlayer(playtestID, playerID uint) (*Feedback, error)
+	Save(*Feedback) error
+}
+
+// LeaveFeedback records a player's response to a playtest. Feedback is only accepted from players
+// once the designer has started the feedback portion of the playtest.
+func LeaveFeedback(playtest *Playtest, player *User, ratings feedback.Ratings, comments, hopingToTestAnswer string) (*Feedback, error) {
+	if !playtest.AcceptingFeedback() {
+		return nil, feedback.NotOpen{}
+	}
+
+	if !playtest.HasPlayer(player) {
+		return nil, feedback.NotAPlayer{}
+	}
+
+	if err := ratings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Feedback{
+		PlaytestID:         playtest.ID,
+		Player:             *player,
+		PlayerID:           player.ID,
+		Ratings:            ratings,
+		Comments:           comments,
+		HopingToTestAnswer: hopingToTestAnswer,
+	}, nil
+}
+
+// Revise replaces a player's earlier response with a new one, as long as the playtest is still taking feedback
+func (f *Feedback) Revise(playtest *Playtest, ratings feedback.Ratings, comments, hopingToTestAnswer string) error {
+	if !playtest.AcceptingFeedback() {
+		return feedback.NotOpen{}
+	}
+
+	if err := ratings.Validate(); err != nil {
+		return err
+	}
+
+	f.Ratings = ratings
+	f.Comments = comments
+	f.HopingToTestAnswer = hopingToTestAnswer
+
+	return nil
+}
+
+// SummarizeFeedback aggregates every response to a playtest for the designers
+func SummarizeFeedback(playtest *Playtest, responses []Feedback) feedback.Summary {
+	summary := feedback.Summary{
+		Responses:    len(responses),
+		HopingToTest: playtest.Requirements.HopingToTest,
+		Answers:      []string{},
+		Comments:     []string{},
+	}
+
+	if len(responses) == 0 {
+		return summary
+	}
+
+	var totals feedback.Ratings
+	for _, r := range responses {
+		totals.Fun += r.Ratings.Fun
+		totals.Clarity += r.Ratings.Clarity
+		totals.Pacing += r.Ratings.Pacing
+		totals.Replayability += r.Ratings.Replayability
+		totals.Overall += r.Ratings.Overall
+
+		if r.HopingToTestAnswer != "" {
+			summary.Answers = append(summary.Answers, r.HopingToTestAnswer)
+		}
+
+		if r.Comments != "" {
+			summary.Comments = append(summary.Comments, r.Comments)
+		}
+	}
+
+	n := float64(len(responses))
+	summary.AverageRatings = feedback.AverageRatings{
+		Fun:           float64(totals.Fun) / n,
+		Clarity:       float64(totals.Clarity) / n,
+		Pacing:        float64(totals.Pacing) / n,
+		Replayability: float64(totals.Replayability) / n,
+		Overall:       float64(totals.Overall) / n,
+	}
+
+	return summary
+}
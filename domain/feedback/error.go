@@ -0,0 +1,27 @@
+package feedback
+
+import "fmt"
+
+// InvalidRating error for scores outside of the allowed range
+type InvalidRating struct {
+	Category      string
+	ProvidedValue uint
+}
+
+func (e InvalidRating) Error() string {
+	return fmt.Sprintf("rating '%s' must be between %d and %d, got %d", e.Category, MinRating, MaxRating, e.ProvidedValue)
+}
+
+// NotOpen error for feedback left before the designer has asked for it
+type NotOpen struct{}
+
+func (e NotOpen) Error() string {
+	return "feedback has not started for this playtest"
+}
+
+// NotAPlayer error for feedback left by someone who didn't play
+type NotAPlayer struct{}
+
+func (e NotAPlayer) Error() string {
+	return "only players of this playtest may leave feedback"
+}
@@ -0,0 +1,38 @@
+package feedback
+
+// Ratings are the structured scores a player gives a playtest. Each is on a scale from 1 (worst) to 5 (best)
+type Ratings struct {
+	Fun           uint `json:"fun" example:"4"`
+	Clarity       uint `json:"clarity" example:"3"`
+	Pacing        uint `json:"pacing" example:"4"`
+	Replayability uint `json:"replayability" example:"5"`
+	Overall       uint `json:"overall" example:"4"`
+}
+
+// MinRating is the lowest score allowed for any category
+const MinRating = 1
+
+// MaxRating is the highest score allowed for any category
+const MaxRating = 5
+
+// Validate ensures every category has been scored within range
+func (r Ratings) Validate() error {
+	categories := []struct {
+		name  string
+		value uint
+	}{
+		{"fun", r.Fun},
+		{"clarity", r.Clarity},
+		{"pacing", r.Pacing},
+		{"replayability", r.Replayability},
+		{"overall", r.Overall},
+	}
+
+	for _, c := range categories {
+		if c.value < MinRating || c.value > MaxRating {
+			return InvalidRating{Category: c.name, ProvidedValue: c.value}
+		}
+	}
+
+	return nil
+}
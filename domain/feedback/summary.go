@@ -0,0 +1,19 @@
+package feedback
+
+// AverageRatings are the mean of each rating category across all responses
+type AverageRatings struct {
+	Fun           float64 `json:"fun" example:"3.75"`
+	Clarity       float64 `json:"clarity" example:"3.5"`
+	Pacing        float64 `json:"pacing" example:"4"`
+	Replayability float64 `json:"replayability" example:"4.25"`
+	Overall       float64 `json:"overall" example:"3.75"`
+}
+
+// Summary is the aggregate of all feedback left for a single playtest
+type Summary struct {
+	Responses      int            `json:"responses" example:"4"`
+	AverageRatings AverageRatings `json:"average_ratings"`
+	HopingToTest   string         `json:"hoping_to_test" example:"Is the kerpluxic mechanic intuitive?"`
+	Answers        []string       `json:"answers" example:"['Yes, after the first round', 'Not really']"`
+	Comments       []string       `json:"comments" example:"['Loved the theme!']"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/feedback"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+func TestLeaveFeedback(t *testing.T) {
+	ratings := feedback.Ratings{Fun: 4, Clarity: 3, Pacing: 4, Replayability: 5, Overall: 4}
+
+	var tests = []struct {
+		playtest      *Playtest
+		player        *User
+		ratings       feedback.Ratings
+		expectedError error
+	}{
+		{&Playtest{State: playtest.Feedback, Players: []User{User{ID: 1}}}, &User{ID: 1}, ratings, nil},
+		{&Playtest{State: playtest.InProgress, Players: []User{User{ID: 1}}}, &User{ID: 1}, ratings, feedback.NotOpen{}},
+		{&Playtest{State: playtest.Feedback, Players: []User{User{ID: 1}}}, &User{ID: 2}, ratings, feedback.NotAPlayer{}},
+		{&Playtest{State: playtest.Feedback, Players: []User{User{ID: 1}}}, &User{ID: 1}, feedback.Ratings{Fun: 6}, feedback.InvalidRating{}},
+	}
+
+	for _, tt := range tests {
+		f, err := LeaveFeedback(tt.playtest, tt.player, tt.ratings, "Comments", "Answer")
+		if tt.expectedError == nil {
+			if err != nil {
+				t.Errorf("Unexpected error leaving feedback: %s", err)
+				continue
+			}
+
+			if f.PlayerID != tt.player.ID || f.Ratings != tt.ratings {
+				t.Error("Feedback not recorded for player")
+			}
+
+			continue
+		}
+
+		switch tt.expectedError.(type) {
+		case feedback.NotOpen:
+			if _, ok := err.(feedback.NotOpen); !ok {
+				t.Errorf("Expected NotOpen error, got '%v'", err)
+			}
+		case feedback.NotAPlayer:
+			if _, ok := err.(feedback.NotAPlayer); !ok {
+				t.Errorf("Expected NotAPlayer error, got '%v'", err)
+			}
+		case feedback.InvalidRating:
+			if _, ok := err.(feedback.InvalidRating); !ok {
+				t.Errorf("Expected InvalidRating error, got '%v'", err)
+			}
+		}
+	}
+}
+
+func TestReviseFeedback(t *testing.T) {
+	ratings := feedback.Ratings{Fun: 4, Clarity: 3, Pacing: 4, Replayability: 5, Overall: 4}
+	p := &Playtest{State: playtest.Feedback, Players: []User{User{ID: 1}}}
+	f, err := LeaveFeedback(p, &User{ID: 1}, ratings, "First", "")
+	if err != nil {
+		t.Fatalf("Unexpected error leaving feedback: %s", err)
+	}
+
+	ratings.Fun = 5
+	if err := f.Revise(p, ratings, "Second", ""); err != nil || f.Ratings.Fun != 5 || f.Comments != "Second" {
+		t.Errorf("Expected feedback to be revised, got %+v (%v)", f, err)
+	}
+
+	p.State = playtest.Cancelled
+	if err := f.Revise(p, ratings, "Third", ""); err != (feedback.NotOpen{}) || f.Comments != "Second" {
+		t.Errorf("Expected feedback to stay put once the playtest stops taking it, got %v", err)
+	}
+}
+
+func TestSummarizeFeedback(t *testing.T) {
+	p := &Playtest{Requirements: playtest.Requirements{HopingToTest: "Is it fun?"}}
+	responses := []Feedback{
+		{Ratings: feedback.Ratings{Fun: 4, Clarity: 2, Pacing: 3, Replayability: 5, Overall: 4}, Comments: "Great", HopingToTestAnswer: "Yes"},
+		{Ratings: feedback.Ratings{Fun: 2, Clarity: 4, Pacing: 3, Replayability: 3, Overall: 3}},
+	}
+
+	summary := SummarizeFeedback(p, responses)
+	if summary.Responses != 2 {
+		t.Errorf("Expected 2 responses, got %d", summary.Responses)
+	}
+
+	if summary.AverageRatings.Fun != 3 || summary.AverageRatings.Overall != 3.5 {
+		t.Errorf("Average ratings incorrect: %+v", summary.AverageRatings)
+	}
+
+	if summary.HopingToTest != "Is it fun?" || len(summary.Answers) != 1 || len(summary.Comments) != 1 {
+		t.Error("Blank answers and comments should be left out of the summary")
+	}
+
+	empty := SummarizeFeedback(p, nil)
+	if empty.Responses != 0 || empty.AverageRatings.Overall != 0 {
+		t.Error("Summary without responses should be empty")
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/lib/pq"
+)
+
+// GameVersion is a named snapshot of a game's stats, mechanics, rules and files. Playtests are pinned
+// to the version that was current when they were registered, so feedback can be read against the
+// rules that were actually played.
+type GameVersion struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	GameID uint `json:"-" gorm:"index"`
+
+	Name      string                  `json:"name" gorm:"not null" example:"v2 - Simplified scoring"`
+	Notes     string                  `json:"notes" example:"Scoring now happens at the end of each round"`
+	Stats     game.Stats              `json:"stats" gorm:"embedded"`
+	Mechanics pq.StringArray          `json:"mechanics" gorm:"type:text[]" example:"['Hidden Movement', 'Worker Placement']"`
+	Rules     []game.VersionedSection `json:"rules,omitempty"`
+	Files     []File                  `json:"files,omitempty" gorm:"many2many:game_version_files;"`
+}
+
+// Snapshot freezes the game as it currently stands into a new version. The game's rules and files must be loaded.
+// The version becomes the game's current version once it has been saved.
+func (g *Game) Snapshot(name, notes string) (*GameVersion, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, game.InvalidVersion{Reason: "versions must be named"}
+	}
+
+	version := &GameVersion{
+		GameID:    g.ID,
+		Name:      name,
+		Notes:     notes,
+		Stats:     g.Stats,
+		Mechanics: append(pq.StringArray{}, g.Mechanics...),
+		Rules:     []game.VersionedSection{},
+		Files:     append([]File{}, g.Files...),
+	}
+
+	rules := append([]game.RulesSection{}, g.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].OrderBy < rules[j].OrderBy
+	})
+
+	for _, section := range rules {
+		version.Rules = append(version.Rules, game.VersionedSection{
+			Title:   section.Title,
+			Content: section.Content,
+			OrderBy: section.OrderBy,
+		})
+	}
+
+	return version, nil
+}
+
+// PinVersion marks a saved version as the one new playtests will be registered against
+func (g *Game) PinVersion(version *GameVersion) {
+	if version == nil || version.ID == 0 || version.GameID != g.ID {
+		return
+	}
+
+	g.CurrentVersionID = &version.ID
+}
+
+// Matches checks if the version still describes the game as it stands: the same stats, mechanics, rules and
+// files. The game's rules and files must be loaded, along with the version's.
+func (v *GameVersion) Matches(g *Game) bool {
+	if v == nil || v.GameID != g.ID {
+		return false
+	}
+
+	live, _ := g.Snapshot("live", "")
+	if live.Stats != v.Stats || strings.Join(live.Mechanics, "\n") != strings.Join(v.Mechanics, "\n") {
+		return false
+	}
+
+	if len(live.Rules) != len(v.Rules) || len(live.Files) != len(v.Files) {
+		return false
+	}
+
+	// Neither side is guaranteed to be loaded in order, so both are sorted before comparing
+	liveRules, pinnedRules := sortedSections(live.Rules), sortedSections(v.Rules)
+	for i, section := range liveRules {
+		pinned := pinnedRules[i]
+		if section.Title != pinned.Title || section.Content != pinned.Content || section.OrderBy != pinned.OrderBy {
+			return false
+		}
+	}
+
+	liveFiles, pinnedFiles := sortedFileIDs(live.Files), sortedFileIDs(v.Files)
+	for i, id := range liveFiles {
+		if id != pinnedFiles[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortedSections copies the sections in a stable order: by their order, then title and content for ties
+func sortedSections(sections []game.VersionedSection) []game.VersionedSection {
+	sorted := append([]game.VersionedSection{}, sections...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].OrderBy != sorted[j].OrderBy {
+			return sorted[i].OrderBy < sorted[j].OrderBy
+		}
+
+		if sorted[i].Title != sorted[j].Title {
+			return sorted[i].Title < sorted[j].Title
+		}
+
+		return sorted[i].Content < sorted[j].Content
+	})
+
+	return sorted
+}
+
+// sortedFileIDs lists the IDs of the files in ascending order
+func sortedFileIDs(files []File) []uint {
+	ids := []uint{}
+	for _, file := range files {
+		ids = append(ids, file.ID)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// PinVersion marks the version of the game the playtest is played with
+func (p *Playtest) PinVersion(version *GameVersion) {
+	if version == nil || version.ID == 0 || version.GameID != p.GameID {
+		return
+	}
+
+	p.GameVersionID = &version.ID
+}
+
+// SnapshotForPlaytest returns a new version for playtests to be pinned to when the game has changed since its
+// current version, so feedback is always read against the rules that were played. It returns nil while the
+// current version still matches. The game's rules and files must be loaded, along with the current version's.
+func (g *Game) SnapshotForPlaytest(current *GameVersion, date time.Time) *GameVersion {
+	if current.Matches(g) {
+		return nil
+	}
+
+	version, _ := g.Snapshot(
+		"Playtest "+date.Format("2006-01-02"),
+		"Taken automatically when a playtest was registered after the game changed",
+	)
+
+	return version
+}
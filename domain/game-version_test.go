@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+func TestSnapshot(t *testing.T) {
+	g := NewGame("First Game", User{ID: 123})
+	g.ID = 1
+	g.ReplaceMechanics([]string{"Worker Placement"})
+	g.Rules = []game.RulesSection{
+		*game.NewRulesSection(1, "Scoring", "Most points wins", 1),
+		*game.NewRulesSection(1, "Components", "52 cards", 0),
+	}
+	g.Files = []File{{ID: 7}}
+
+	if _, err := g.Snapshot("  ", ""); err == nil {
+		t.Error("Versions without a name should be rejected")
+	}
+
+	v, err := g.Snapshot("v1", "First printing")
+	if err != nil {
+		t.Fatalf("Unexpected error snapshotting game: %s", err)
+	}
+
+	if v.GameID != 1 || v.Name != "v1" || v.Stats != g.Stats || len(v.Mechanics) != 1 || len(v.Files) != 1 {
+		t.Errorf("Snapshot incorrect: %+v", v)
+	}
+
+	if len(v.Rules) != 2 || v.Rules[0].Title != "Components" || v.Rules[1].Title != "Scoring" {
+		t.Errorf("Rules should be copied in order, got %+v", v.Rules)
+	}
+
+	// Later changes to the game shouldn't leak into the snapshot
+	g.ReplaceMechanics([]string{"Deck Building"})
+	g.UpdateStats(2, 4, 10, 45)
+	if v.Mechanics[0] != "Worker Placement" || v.Stats.MinPlayers != 1 {
+		t.Error("Snapshot changed along with the game")
+	}
+
+	if g.CurrentVersionID != nil {
+		t.Error("Versions shouldn't be pinned until they've been saved")
+	}
+
+	v.ID = 3
+	g.PinVersion(v)
+	if g.CurrentVersionID == nil || *g.CurrentVersionID != 3 {
+		t.Error("Saved version should be pinned")
+	}
+
+	g.PinVersion(&GameVersion{ID: 4, GameID: 2})
+	if *g.CurrentVersionID != 3 {
+		t.Error("Versions of other games shouldn't be pinned")
+	}
+}
+
+func TestRegisterGamePinsVersion(t *testing.T) {
+	version := uint(3)
+	g := &Game{ID: 1, CurrentVersionID: &version}
+
+	p, err := RegisterGame(g, nil, time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC), 0, 2, 4, 60, true, "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error registering game: %s", err)
+	}
+
+	if p.GameVersionID == nil || *p.GameVersionID != 3 {
+		t.Error("Playtest should be pinned to the game's current version")
+	}
+
+	p, _ = RegisterGame(&Game{ID: 1}, nil, time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC), 0, 2, 4, 60, true, "", "", "")
+	if p.GameVersionID != nil {
+		t.Error("Games without versions shouldn't pin one")
+	}
+}
+
+func TestSnapshotForPlaytest(t *testing.T) {
+	g := &Game{
+		ID:        1,
+		Mechanics: []string{"Dice Rolling"},
+		Rules:     []game.RulesSection{{ID: 2, Title: "Setup", Content: "<p>Shuffle</p>"}},
+		Files:     []File{{ID: 4}},
+	}
+	date := time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	version := g.SnapshotForPlaytest(nil, date)
+	if version == nil || version.Name != "Playtest 2020-12-16" {
+		t.Fatalf("Expected games without a version to be snapshotted, got %+v", version)
+	}
+
+	version.ID = 3
+	if g.SnapshotForPlaytest(version, date) != nil {
+		t.Error("Expected the current version to be reused while the game is unchanged")
+	}
+
+	g.Rules[0].Content = "<p>Deal 5</p>"
+	if g.SnapshotForPlaytest(version, date) == nil {
+		t.Error("Expected changed rules to be snapshotted")
+	}
+
+	g.Rules[0].Content = "<p>Shuffle</p>"
+	g.Mechanics = append(g.Mechanics, "Drafting")
+	if g.SnapshotForPlaytest(version, date) == nil {
+		t.Error("Expected changed mechanics to be snapshotted")
+	}
+
+	// Rules and files loaded in another order are still the same game
+	g.Mechanics = []string{"Dice Rolling"}
+	g.Rules = []game.RulesSection{{ID: 2, Title: "Setup", Content: "<p>Shuffle</p>"}, {ID: 5, Title: "Scoring", Content: "<p>Count</p>"}}
+	g.Files = []File{{ID: 4}, {ID: 6}}
+	version = g.SnapshotForPlaytest(nil, date)
+	version.ID = 7
+	version.Rules[0], version.Rules[1] = version.Rules[1], version.Rules[0]
+	version.Files[0], version.Files[1] = version.Files[1], version.Files[0]
+	if g.SnapshotForPlaytest(version, date) != nil {
+		t.Error("Expected the load order of rules and files not to matter")
+	}
+}
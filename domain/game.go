@@ -21,18 +21,38 @@ type Game struct {
 	Stats     game.Stats          `json:"stats" gorm:"embedded"`
 	Mechanics pq.StringArray      `json:"mechanics" gorm:"type:text[]" example:"['Hidden Movement', 'Worker Placement']"`
 	Designers []User              `json:"designers" gorm:"many2many:game_designers;"`
+	OwnerID   uint                `json:"owner_id" gorm:"not null;default:0;index" example:"123"` // Designer in charge of who else designs the game
 	Files     []File              `json:"files"`
 	Rules     []game.RulesSection `json:"-"`
 
+	CurrentVersionID *uint `json:"current_version_id,omitempty" example:"123"` // Version new playtests are pinned to
+
+	Publisher    *Publisher `json:"publisher,omitempty"` // Set while signed or published
+	PublisherID  *uint      `json:"-"`
+	ContractDate *time.Time `json:"contract_date,omitempty" example:"2021-03-01T00:00:00Z"`
+
 	TabletopSimulatorMod int `json:"tts_mod" example:"2247242964"`
 }
 
 // GameRepository defines how to interact with games in database
 type GameRepository interface {
-	ListGames(title, status, designer string, owner uint, playerCount, age, playtime, limit, offset int, sort string) ([]Game, int, error)
+	ListGames(search, title, status, designer string, owner uint, playerCount, age, playtime, limit, offset int, sort string) ([]Game, int, error)
+	HighlightGames(ids []uint, search string) ([]game.Highlight, error)
 	GameOfID(id uint) (*Game, error)
 	RulesOfGame(id uint) ([]game.RulesSection, error)
+	SaveRulesSection(*game.RulesSection, *RulesRevision) error
+	DeleteRulesSection(*game.RulesSection, *RulesRevision) error
+	ReorderRules([]game.RulesSection, []RulesRevision) error
+	ApplyRulesChanges([]RulesChange) error
+	RewriteContent(rewrite func(string) string) (int, error)
+	RevisionsOfGame(gameID, sectionID uint, limit, offset int) ([]RulesRevision, int, error)
+	RevisionOfID(id uint) (*RulesRevision, error)
+	VersionsOfGame(id uint) ([]GameVersion, error)
+	VersionOfID(id uint) (*GameVersion, error)
+	StatusHistory(gameID uint) ([]StatusChange, error)
 	Save(*Game) error
+	SaveStatusChange(*Game, *StatusChange) error
+	SaveVersion(*Game, *GameVersion) error
 }
 
 // NewGame creates a bare-bones game with a title and designer
@@ -41,6 +61,7 @@ func NewGame(title string, primaryDesigner User) *Game {
 		Title:     title,
 		Status:    game.Prototype,
 		Designers: []User{primaryDesigner},
+		OwnerID:   primaryDesigner.ID,
 		Stats: game.Stats{
 			MinPlayers:        1,
 			MaxPlayers:        5,
@@ -73,23 +94,17 @@ func (g *Game) Rename(newTitle string) {
 	}
 }
 
-// UpdateOverview will change the overview for the game. Blank overviews are not allowed.
-func (g *Game) UpdateOverview(newOverview string) {
-	if newOverview != "" && g.Overview != newOverview {
-		g.Overview = newOverview
+// UpdateOverview will change the overview for the game, sanitized down to the HTML we allow. Blank overviews
+// are not allowed. Any markup that was stripped out is returned.
+func (g *Game) UpdateOverview(newOverview string) []game.Rejection {
+	if newOverview == "" {
+		return []game.Rejection{}
 	}
-}
 
-// UpdateStatus will set the status of the game, provided the status exists
-func (g *Game) UpdateStatus(ns string) error {
-	newStatus, err := game.StatusFromString(ns)
-	if err != nil {
-		return err
-	}
-
-	g.Status = newStatus
+	overview, rejected := game.SanitizeHTML(newOverview)
+	g.Overview = overview
 
-	return nil
+	return rejected
 }
 
 // AddDesigner will include the provider user as a designer on this game.
@@ -111,14 +126,6 @@ func (g *Game) AddDesigner(designer *User) {
 	g.Designers = append(g.Designers, *designer)
 }
 
-// ReplaceDesigners will overwrite the existing designer list with the newly provided one
-func (g *Game) ReplaceDesigners(designers []User) {
-	g.Designers = nil
-	for _, designer := range designers {
-		g.AddDesigner(&designer)
-	}
-}
-
 // UpdateStats will replace the existing game stats with the provided values
 func (g *Game) UpdateStats(minPlayers, maxPlayers, minAge, estimatedPlaytime int) {
 	if minPlayers != 0 {
@@ -135,7 +142,8 @@ func (g *Game) UpdateStats(minPlayers, maxPlayers, minAge, estimatedPlaytime int
 	}
 }
 
-// ReplaceMechanics will overwite the existing mechanics list with the new one
+// ReplaceMechanics will overwite the existing mechanics list with the new one. Mechanics should already be
+// resolved against the taxonomy with ResolveMechanics.
 func (g *Game) ReplaceMechanics(mechanics []string) {
 	g.Mechanics = nil
 	for _, mechanic := range mechanics {
@@ -143,6 +151,58 @@ func (g *Game) ReplaceMechanics(mechanics []string) {
 	}
 }
 
+// RulesSection finds one of the game's rules sections. The game's rules must be loaded.
+func (g *Game) RulesSection(id uint) (*game.RulesSection, error) {
+	for i := range g.Rules {
+		if g.Rules[i].ID == id {
+			return &g.Rules[i], nil
+		}
+	}
+
+	return nil, game.SectionNotFound{ProvidedID: id}
+}
+
+// AddRulesSection appends a new section to the end of the game's rules. Its content is sanitized, and any
+// markup that was stripped out is returned.
+func (g *Game) AddRulesSection(title, content string) (*game.RulesSection, []game.Rejection) {
+	var order uint
+	for _, section := range g.Rules {
+		if section.OrderBy >= order {
+			order = section.OrderBy + 1
+		}
+	}
+
+	g.Rules = append(g.Rules, *game.NewRulesSection(g.ID, title, "", order))
+	section := &g.Rules[len(g.Rules)-1]
+
+	return section, section.UpdateContent(content)
+}
+
+// RemoveRulesSection takes a section out of the game's rules and returns it
+func (g *Game) RemoveRulesSection(id uint) (*game.RulesSection, error) {
+	for i, section := range g.Rules {
+		if section.ID == id {
+			g.Rules = append(g.Rules[:i:i], g.Rules[i+1:]...)
+			return &section, nil
+		}
+	}
+
+	return nil, game.SectionNotFound{ProvidedID: id}
+}
+
+// ReorderRules arranges the game's rules sections to match the provided list of section IDs.
+// Every section must be listed exactly once.
+func (g *Game) ReorderRules(order []uint) error {
+	rules, err := game.Reorder(g.Rules, order)
+	if err != nil {
+		return err
+	}
+
+	g.Rules = rules
+
+	return nil
+}
+
 // LinkTabletopSimulatorMod will link the specified mod to this game
 func (g *Game) LinkTabletopSimulatorMod(mod int) {
 	g.TabletopSimulatorMod = mod
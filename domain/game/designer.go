@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvitationStatus tracks whether someone has answered an invitation to co-design a game
+type InvitationStatus string
+
+const (
+	// InvitationPending invitations are waiting on an answer
+	InvitationPending InvitationStatus = "Pending"
+
+	// InvitationAccepted invitations made the invitee a designer
+	InvitationAccepted InvitationStatus = "Accepted"
+
+	// InvitationDeclined invitations were turned down by the invitee
+	InvitationDeclined InvitationStatus = "Declined"
+
+	// InvitationCancelled invitations were withdrawn by the game's designers before being answered
+	InvitationCancelled InvitationStatus = "Cancelled"
+)
+
+// DesignerAction describes a single change to who designs a game
+type DesignerAction string
+
+const (
+	// DesignerInvited someone was asked to co-design the game
+	DesignerInvited DesignerAction = "Invited"
+
+	// DesignerJoined an invitee accepted and became a designer
+	DesignerJoined DesignerAction = "Joined"
+
+	// DesignerDeclined an invitee turned down their invitation
+	DesignerDeclined DesignerAction = "Declined"
+
+	// InvitationWithdrawn a pending invitation was cancelled
+	InvitationWithdrawn DesignerAction = "InvitationCancelled"
+
+	// DesignerRemoved a designer was taken off the game by its owner
+	DesignerRemoved DesignerAction = "Removed"
+
+	// DesignerLeft a designer took themselves off the game
+	DesignerLeft DesignerAction = "Left"
+
+	// OwnershipTransferred the game was handed to another of its designers
+	OwnershipTransferred DesignerAction = "OwnershipTransferred"
+)
+
+// LastDesigner returned when removing a game's only designer
+type LastDesigner struct{}
+
+func (e LastDesigner) Error() string {
+	return "a game must have at least one designer"
+}
+
+// OwnerRemoval returned when removing a game's owner, who has to hand the game to someone else first
+type OwnerRemoval struct{}
+
+func (e OwnerRemoval) Error() string {
+	return "the owner must transfer ownership before leaving the game"
+}
+
+// NotADesigner returned when the user is expected to design the game, but doesn't
+type NotADesigner struct {
+	ProvidedID uint
+}
+
+func (e NotADesigner) Error() string {
+	return fmt.Sprintf("user '%d' is not a designer of this game", e.ProvidedID)
+}
+
+// AlreadyDesigner returned when inviting someone who already designs the game
+type AlreadyDesigner struct {
+	Name string
+}
+
+func (e AlreadyDesigner) Error() string {
+	return fmt.Sprintf("%s is already a designer of this game", e.Name)
+}
+
+// AlreadyInvited returned when inviting someone with an invitation waiting on an answer
+type AlreadyInvited struct{}
+
+func (e AlreadyInvited) Error() string {
+	return "an invitation is already waiting on an answer"
+}
+
+// InvitationClosed returned when answering or cancelling an invitation that's no longer pending
+type InvitationClosed struct {
+	Status InvitationStatus
+}
+
+func (e InvitationClosed) Error() string {
+	return fmt.Sprintf("invitation has already been %s", strings.ToLower(string(e.Status)))
+}
@@ -0,0 +1,130 @@
+package game
+
+import "strings"
+
+// DiffOp marks whether a line was kept, added or removed between two versions of some text
+type DiffOp string
+
+const (
+	// Unchanged lines appear in both versions
+	Unchanged DiffOp = " "
+
+	// Added lines only appear in the newer version
+	Added = "+"
+
+	// Removed lines only appear in the older version
+	Removed = "-"
+)
+
+// LineDiff is a single line of a text diff
+type LineDiff struct {
+	Op   DiffOp `json:"op" example:"+"`
+	Text string `json:"text" example:"<li>10 dice</li>"`
+}
+
+// SectionStatus describes what happened to a rules section between two points in time
+type SectionStatus string
+
+const (
+	// SectionAdded sections didn't exist at the earlier point
+	SectionAdded SectionStatus = "Added"
+
+	// SectionRemoved sections no longer exist at the later point
+	SectionRemoved = "Removed"
+
+	// SectionChanged sections had their title, content or position changed
+	SectionChanged = "Changed"
+
+	// SectionUnchanged sections are identical at both points
+	SectionUnchanged = "Unchanged"
+)
+
+// SectionDiff compares a single rules section at two points in time
+type SectionDiff struct {
+	SectionID uint          `json:"section_id" example:"123"`
+	Status    SectionStatus `json:"status" example:"Changed"`
+	FromTitle string        `json:"from_title,omitempty" example:"Components"`
+	ToTitle   string        `json:"to_title,omitempty" example:"Components"`
+	Lines     []LineDiff    `json:"lines"`
+}
+
+// blockBreaks puts block-level HTML elements on lines of their own, since rules are usually saved as a single
+// line of markup and a diff of that one line wouldn't tell anyone much
+var blockBreaks = strings.NewReplacer(
+	"</p>", "</p>\n",
+	"</li>", "</li>\n",
+	"</h1>", "</h1>\n",
+	"</h2>", "</h2>\n",
+	"</h3>", "</h3>\n",
+	"</h4>", "</h4>\n",
+	"</tr>", "</tr>\n",
+	"<br>", "<br>\n",
+	"<br/>", "<br/>\n",
+	"<ul>", "<ul>\n",
+	"</ul>", "</ul>\n",
+	"<ol>", "<ol>\n",
+	"</ol>", "</ol>\n",
+)
+
+// SplitLines breaks content into the lines a diff is made of. Blank lines are dropped.
+func SplitLines(content string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(blockBreaks.Replace(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// DiffLines compares two versions of some text line by line, using the longest common subsequence of lines
+func DiffLines(from, to string) []LineDiff {
+	a, b := SplitLines(from), SplitLines(to)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	diff := []LineDiff{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, LineDiff{Op: Unchanged, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, LineDiff{Op: Removed, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, LineDiff{Op: Added, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		diff = append(diff, LineDiff{Op: Removed, Text: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		diff = append(diff, LineDiff{Op: Added, Text: b[j]})
+	}
+
+	return diff
+}
@@ -0,0 +1,37 @@
+package game
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	from := "<ul><li>52 Cards</li><li>10 dice</li></ul><p>Shuffle the deck.</p>"
+	to := "<ul><li>52 Cards</li><li>12 dice</li></ul><p>Shuffle the deck.</p><p>Deal 5 cards.</p>"
+
+	diff := DiffLines(from, to)
+	expected := []LineDiff{
+		{Unchanged, "<ul>"},
+		{Unchanged, "<li>52 Cards</li>"},
+		{Removed, "<li>10 dice</li>"},
+		{Added, "<li>12 dice</li>"},
+		{Unchanged, "</ul>"},
+		{Unchanged, "<p>Shuffle the deck.</p>"},
+		{Added, "<p>Deal 5 cards.</p>"},
+	}
+
+	if len(diff) != len(expected) {
+		t.Fatalf("Expected %d lines, got %+v", len(expected), diff)
+	}
+
+	for i := range expected {
+		if diff[i] != expected[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, expected[i], diff[i])
+		}
+	}
+
+	if diff := DiffLines("", "One\nTwo"); len(diff) != 2 || diff[0].Op != Added || diff[1].Op != Added {
+		t.Errorf("Everything should be added when starting from nothing, got %+v", diff)
+	}
+
+	if diff := DiffLines("One\n\nTwo", "One\nTwo"); len(diff) != 2 || diff[0].Op != Unchanged || diff[1].Op != Unchanged {
+		t.Errorf("Blank lines shouldn't count as changes, got %+v", diff)
+	}
+}
@@ -0,0 +1,9 @@
+package game
+
+// Highlight shows where a game matched a search, with the matching words wrapped in <mark> tags
+type Highlight struct {
+	GameID   uint   `json:"game_id" example:"123"`
+	Title    string `json:"title" example:"The <mark>Best</mark> Game"`
+	Overview string `json:"overview" example:"In the <mark>Best</mark> Game, players take on the role of ..."`
+	Rules    string `json:"rules,omitempty" example:"... the player with the <mark>best</mark> hand wins ..."`
+}
@@ -1,10 +1,16 @@
 package game
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // Mechanic is the name of a construct used in a game system
 type Mechanic string
 
-// AvailableMechanics lists all the mechanic options available
-func AvailableMechanics() []string {
+// DefaultMechanics is the taxonomy mechanics start out with, before admins add to or merge any of them
+func DefaultMechanics() []string {
 	return []string{
 		"Action Blocking",
 		"Action Points",
@@ -62,3 +68,29 @@ func AvailableMechanics() []string {
 		"X And Write",
 	}
 }
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify turns a mechanic's name into a URL-friendly identifier, e.g. "Action/Role Selection" becomes
+// "action-role-selection". Names that only differ by case or punctuation share a slug.
+func Slugify(name string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// UnknownMechanics returned when a game is tagged with mechanics that aren't in the taxonomy
+type UnknownMechanics struct {
+	Names []string
+}
+
+func (e UnknownMechanics) Error() string {
+	return fmt.Sprintf("unknown mechanics: %s", strings.Join(e.Names, ", "))
+}
+
+// InvalidMechanic returned when a mechanic can't be added to, or merged within, the taxonomy
+type InvalidMechanic struct {
+	Reason string
+}
+
+func (e InvalidMechanic) Error() string {
+	return "invalid mechanic: " + e.Reason
+}
@@ -0,0 +1,22 @@
+package game
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	var tests = []struct {
+		name         string
+		expectedSlug string
+	}{
+		{"Worker Placement", "worker-placement"},
+		{"Action/Role Selection", "action-role-selection"},
+		{"  X And Write  ", "x-and-write"},
+		{"One Vs. Many", "one-vs-many"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		if actual := Slugify(tt.name); actual != tt.expectedSlug {
+			t.Errorf("Slugify(%q) expected %q, got %q", tt.name, tt.expectedSlug, actual)
+		}
+	}
+}
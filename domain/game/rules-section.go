@@ -1,6 +1,9 @@
 package game
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // RulesSection is a single section in a rule book
 type RulesSection struct {
@@ -31,9 +34,13 @@ func (s *RulesSection) UpdateTitle(newTitle string) {
 	s.Title = newTitle
 }
 
-// UpdateContent will replace the content
-func (s *RulesSection) UpdateContent(newContent string) {
-	s.Content = newContent
+// UpdateContent will replace the content, sanitized down to the HTML we allow. Any markup that was stripped
+// out is returned.
+func (s *RulesSection) UpdateContent(newContent string) []Rejection {
+	content, rejected := SanitizeHTML(newContent)
+	s.Content = content
+
+	return rejected
 }
 
 // UpdateOrder will simply accept the new order provided.
@@ -41,3 +48,57 @@ func (s *RulesSection) UpdateContent(newContent string) {
 func (s *RulesSection) UpdateOrder(order uint) {
 	s.OrderBy = order
 }
+
+// Reorder arranges the sections to match the provided list of section IDs, which must include every
+// section exactly once. Sections are renumbered from 0 and returned in their new order.
+func Reorder(sections []RulesSection, order []uint) ([]RulesSection, error) {
+	if len(order) != len(sections) {
+		return nil, InvalidOrder{Reason: fmt.Sprintf("expected %d sections, got %d", len(sections), len(order))}
+	}
+
+	byID := map[uint]RulesSection{}
+	for _, section := range sections {
+		byID[section.ID] = section
+	}
+
+	reordered := []RulesSection{}
+	for i, id := range order {
+		section, found := byID[id]
+		if !found {
+			return nil, InvalidOrder{Reason: fmt.Sprintf("section '%d' is missing or listed twice", id)}
+		}
+
+		delete(byID, id)
+		section.UpdateOrder(uint(i))
+		reordered = append(reordered, section)
+	}
+
+	return reordered, nil
+}
+
+// InvalidOrder returned when a new order for a game's rules doesn't cover each section exactly once
+type InvalidOrder struct {
+	Reason string
+}
+
+func (e InvalidOrder) Error() string {
+	return "invalid order: " + e.Reason
+}
+
+// SectionNotFound returned when a rules section doesn't exist, or belongs to another game
+type SectionNotFound struct {
+	ProvidedID uint
+}
+
+func (e SectionNotFound) Error() string {
+	return fmt.Sprintf("rules section '%d' not found", e.ProvidedID)
+}
+
+// RevisionNotFound returned when a rules revision doesn't exist, belongs to another game or can't be restored
+type RevisionNotFound struct {
+	ProvidedID uint
+}
+
+func (e RevisionNotFound) Error() string {
+	return fmt.Sprintf("rules revision '%d' not found", e.ProvidedID)
+}
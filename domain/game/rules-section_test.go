@@ -0,0 +1,43 @@
+package game
+
+import "testing"
+
+func TestReorder(t *testing.T) {
+	sections := []RulesSection{
+		{ID: 1, Title: "Setup", OrderBy: 0},
+		{ID: 2, Title: "Scoring", OrderBy: 1},
+		{ID: 3, Title: "Components", OrderBy: 2},
+	}
+
+	reordered, err := Reorder(sections, []uint{3, 1, 2})
+	if err != nil {
+		t.Fatalf("Unexpected error reordering sections: %s", err)
+	}
+
+	for i, id := range []uint{3, 1, 2} {
+		if reordered[i].ID != id || reordered[i].OrderBy != uint(i) {
+			t.Errorf("Section %d incorrect: %+v", i, reordered[i])
+		}
+	}
+
+	var tests = []struct {
+		order []uint
+		desc  string
+	}{
+		{[]uint{1, 2}, "missing a section"},
+		{[]uint{1, 2, 2}, "listing a section twice"},
+		{[]uint{1, 2, 4}, "including another game's section"},
+	}
+
+	for _, tt := range tests {
+		if _, err := Reorder(sections, tt.order); err == nil {
+			t.Errorf("Reordering should fail when %s", tt.desc)
+		} else if _, ok := err.(InvalidOrder); !ok {
+			t.Errorf("Expected InvalidOrder error when %s, got '%v'", tt.desc, err)
+		}
+	}
+
+	if sections[0].OrderBy != 0 || sections[2].OrderBy != 2 {
+		t.Error("Reordering shouldn't touch the original sections")
+	}
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Rejection describes a piece of markup that was stripped out while sanitizing HTML
+type Rejection struct {
+	Markup string `json:"markup" example:"<img onerror=\"alert(1)\">"`
+	Reason string `json:"reason" example:"attribute not allowed"`
+}
+
+// allowedElements lists the markup we allow in rules and overviews, along with the attributes each element
+// may keep. Anything else is unwrapped, leaving its text behind.
+var allowedElements = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil, atom.Div: nil, atom.Span: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil, atom.S: nil, atom.Del: nil,
+	atom.Sub: nil, atom.Sup: nil, atom.Blockquote: nil, atom.Pre: nil, atom.Code: nil,
+	atom.Ul: nil, atom.Ol: {"start"}, atom.Li: nil, atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tfoot: nil, atom.Tr: nil,
+	atom.Th: {"colspan", "rowspan"}, atom.Td: {"colspan", "rowspan"},
+	atom.A:   {"href", "title"},
+	atom.Img: {"src", "alt", "title", "width", "height"},
+}
+
+// droppedElements are removed along with everything inside them, since their content isn't meant to be read
+var droppedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Select: true, atom.Svg: true,
+	atom.Math: true,
+}
+
+// urlAttributes must hold links to the web (or mail, for anchors), or relative ones
+var urlAttributes = map[string][]string{
+	"href": {"http", "https", "mailto"},
+	"src":  {"http", "https"},
+}
+
+// SanitizeHTML strips user provided HTML down to the elements and attributes we allow, reporting everything
+// it had to remove. Text is always kept, except inside elements like <script>.
+func SanitizeHTML(content string) (string, []Rejection) {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		return html.EscapeString(content), []Rejection{{Markup: content, Reason: "could not be parsed"}}
+	}
+
+	rejected := []Rejection{}
+	for _, n := range nodes {
+		context.AppendChild(n)
+	}
+	sanitizeChildren(context, &rejected)
+
+	var buf bytes.Buffer
+	for n := context.FirstChild; n != nil; n = n.NextSibling {
+		if err := html.Render(&buf, n); err != nil {
+			return html.EscapeString(content), []Rejection{{Markup: content, Reason: "could not be parsed"}}
+		}
+	}
+
+	return buf.String(), rejected
+}
+
+func sanitizeChildren(parent *html.Node, rejected *[]Rejection) {
+	for n := parent.FirstChild; n != nil; {
+		next := n.NextSibling
+
+		switch n.Type {
+		case html.TextNode:
+			// Always allowed
+		case html.ElementNode:
+			sanitizeElement(n, rejected)
+		default:
+			// Comments and doctypes have no place in content
+			parent.RemoveChild(n)
+		}
+
+		n = next
+	}
+}
+
+func sanitizeElement(n *html.Node, rejected *[]Rejection) {
+	parent := n.Parent
+
+	if droppedElements[n.DataAtom] {
+		*rejected = append(*rejected, Rejection{Markup: tag(n, nil), Reason: "element not allowed"})
+		parent.RemoveChild(n)
+		return
+	}
+
+	allowed, ok := allowedElements[n.DataAtom]
+	if !ok {
+		// Keep the element's content in its place
+		*rejected = append(*rejected, Rejection{Markup: tag(n, nil), Reason: "element not allowed"})
+		sanitizeChildren(n, rejected)
+		for child := n.FirstChild; child != nil; child = n.FirstChild {
+			n.RemoveChild(child)
+			parent.InsertBefore(child, n)
+		}
+		parent.RemoveChild(n)
+		return
+	}
+
+	attrs := []html.Attribute{}
+	for _, a := range n.Attr {
+		if !contains(allowed, a.Key) || a.Namespace != "" {
+			*rejected = append(*rejected, Rejection{Markup: tag(n, &a), Reason: "attribute not allowed"})
+			continue
+		}
+
+		if schemes, isURL := urlAttributes[a.Key]; isURL && !safeURL(a.Val, schemes) {
+			*rejected = append(*rejected, Rejection{Markup: tag(n, &a), Reason: "unsafe URL"})
+			continue
+		}
+
+		attrs = append(attrs, a)
+	}
+	n.Attr = attrs
+
+	sanitizeChildren(n, rejected)
+}
+
+// safeURL is true for relative URLs and those using one of the provided schemes
+func safeURL(value string, schemes []string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "" || contains(schemes, strings.ToLower(u.Scheme))
+}
+
+// tag describes an element, and optionally a single attribute of it, as it would have appeared in the markup
+func tag(n *html.Node, a *html.Attribute) string {
+	if a == nil {
+		return fmt.Sprintf("<%s>", n.Data)
+	}
+
+	return fmt.Sprintf("<%s %s=%q>", n.Data, a.Key, a.Val)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package game
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	var tests = []struct {
+		content  string
+		expected string
+		rejected []Rejection
+	}{
+		{"<ul><li>52 Cards</li><li>10 dice</li></ul>", "<ul><li>52 Cards</li><li>10 dice</li></ul>", nil},
+		{"Plain text & more", "Plain text &amp; more", nil},
+		{"<p>Shuffle<script>alert(1)</script></p>", "<p>Shuffle</p>", []Rejection{{"<script>", "element not allowed"}}},
+		{`<img src="x.png" onerror="alert(1)">`, `<img src="x.png"/>`, []Rejection{{`<img onerror="alert(1)">`, "attribute not allowed"}}},
+		{`<a href="javascript:alert(1)">Rules</a>`, "<a>Rules</a>", []Rejection{{`<a href="javascript:alert(1)">`, "unsafe URL"}}},
+		{`<a href="/games/1" title="Game">Rules</a>`, `<a href="/games/1" title="Game">Rules</a>`, nil},
+		{"<font color=red><b>Bold</b></font>", "<b>Bold</b>", []Rejection{{"<font>", "element not allowed"}}},
+		{"<p>Setup<!-- raw HTML omitted --></p>", "<p>Setup</p>", nil},
+	}
+
+	for _, tt := range tests {
+		actual, rejected := SanitizeHTML(tt.content)
+		if actual != tt.expected {
+			t.Errorf("SanitizeHTML(%q) expected %q, got %q", tt.content, tt.expected, actual)
+		}
+
+		if len(rejected) != len(tt.rejected) {
+			t.Errorf("SanitizeHTML(%q) expected rejections %v, got %v", tt.content, tt.rejected, rejected)
+			continue
+		}
+
+		for i := range rejected {
+			if rejected[i] != tt.rejected[i] {
+				t.Errorf("SanitizeHTML(%q) expected rejection %v, got %v", tt.content, tt.rejected[i], rejected[i])
+			}
+		}
+	}
+}
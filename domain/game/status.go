@@ -21,6 +21,26 @@ const (
 	Archived = "Archived"
 )
 
+// statusTransitions lists every status a game may move to from its current status. Signed games go back to
+// being prototypes when their contract ends, and archived games can only be revived as prototypes.
+var statusTransitions = map[Status][]Status{
+	Prototype: {Signed, Published, Archived},
+	Signed:    {Prototype, Published, Archived},
+	Published: {Archived},
+	Archived:  {Prototype},
+}
+
+// CanTransitionTo checks if moving from this status to the next one is allowed
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
 // StatusFromString returns the Status type corresponding to the provided string
 func StatusFromString(s string) (Status, error) {
 	switch s {
@@ -45,3 +65,20 @@ type InvalidStatus struct {
 func (e InvalidStatus) Error() string {
 	return fmt.Sprintf("invalid status '%s'", e.PassedValue)
 }
+
+// InvalidTransition returned when a game is asked to move between statuses that aren't connected
+type InvalidTransition struct {
+	From Status
+	To   Status
+}
+
+func (e InvalidTransition) Error() string {
+	return fmt.Sprintf("game cannot move from '%s' to '%s'", e.From, e.To)
+}
+
+// ContractRequired returned when a game is signed without saying who signed it and when
+type ContractRequired struct{}
+
+func (e ContractRequired) Error() string {
+	return "signing a game requires the publisher and contract date"
+}
@@ -0,0 +1,25 @@
+package game
+
+import "time"
+
+// VersionedSection is a copy of a rules section, frozen as it was when a version of the game was snapshotted
+type VersionedSection struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	GameVersionID uint `json:"-" gorm:"index"`
+
+	Title   string `json:"title" gorm:"not null" example:"Components"`
+	Content string `json:"content" example:"<ul><li>52 Cards</li><li>10 dice</li>..."`
+
+	OrderBy uint `json:"order" example:"0"`
+}
+
+// InvalidVersion returned when a version can't be snapshotted
+type InvalidVersion struct {
+	Reason string
+}
+
+func (e InvalidVersion) Error() string {
+	return "invalid version: " + e.Reason
+}
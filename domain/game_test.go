@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
 )
@@ -20,6 +22,10 @@ func TestNewGame(t *testing.T) {
 		t.Error("Primary designer not set on new game")
 	}
 
+	if g.OwnerID != 123 {
+		t.Error("Primary designer does not own new game")
+	}
+
 	if g.Stats.MinPlayers != 1 || g.Stats.MaxPlayers != 5 || g.Stats.MinAge != 8 || g.Stats.EstimatedPlaytime != 30 {
 		t.Error("Game stats are not set to expected defaults on new game")
 	}
@@ -73,6 +79,7 @@ func TestUpdateOverview(t *testing.T) {
 		{&Game{Overview: "Original Overview"}, "New Overview", "New Overview"},
 		{&Game{Overview: "Original Overview"}, "", "Original Overview"},
 		{&Game{Overview: "Original Overview"}, "Original Overview", "Original Overview"},
+		{&Game{Overview: "Original Overview"}, `<p onclick="steal()">Race</p>`, "<p>Race</p>"},
 	}
 
 	for _, tt := range tests {
@@ -84,23 +91,34 @@ func TestUpdateOverview(t *testing.T) {
 	}
 }
 
-func TestUpdateStatus(t *testing.T) {
+func TestChangeStatus(t *testing.T) {
+	contractDate := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	p := &Publisher{ID: 2, Name: "Coin Flip Games"}
+
 	var tests = []struct {
 		game           *Game
 		newStatus      string
+		publisher      *Publisher
+		contractDate   *time.Time
 		expectedStatus game.Status
 		expectedError  error
 	}{
-		{&Game{Status: game.Prototype}, "Published", game.Published, nil},
-		{&Game{Status: game.Prototype}, "Not a status", game.Prototype, game.InvalidStatus{}},
+		{&Game{Status: game.Prototype}, "Published", nil, nil, game.Published, nil},
+		{&Game{Status: game.Prototype}, "Signed", p, &contractDate, game.Signed, nil},
+		{&Game{Status: game.Prototype}, "Signed", nil, &contractDate, game.Prototype, game.ContractRequired{}},
+		{&Game{Status: game.Archived}, "Published", nil, nil, game.Archived, game.InvalidTransition{}},
+		{&Game{Status: game.Published}, "Published", nil, nil, game.Published, game.InvalidTransition{}},
+		{&Game{Status: game.Prototype}, "Not a status", nil, nil, game.Prototype, game.InvalidStatus{}},
 	}
 
 	for _, tt := range tests {
-		err := tt.game.UpdateStatus(tt.newStatus)
+		change, err := tt.game.ChangeStatus(tt.newStatus, &User{ID: 1}, "", tt.publisher, tt.contractDate)
 		if tt.expectedError != nil {
-			if _, ok := err.(game.InvalidStatus); !ok {
-				t.Errorf("Expected error on invalid status, got none")
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.expectedError) {
+				t.Errorf("Expected %T moving to '%s', got %v", tt.expectedError, tt.newStatus, err)
 			}
+		} else if err != nil || change.To != tt.expectedStatus || change.ChangedByID != 1 {
+			t.Errorf("Expected change to '%s', got %+v (%v)", tt.newStatus, change, err)
 		}
 
 		actual := tt.game.Status
@@ -110,6 +128,33 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestChangeStatusContract(t *testing.T) {
+	contractDate := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	g := &Game{Status: game.Prototype}
+
+	change, err := g.ChangeStatus("Signed", &User{ID: 1}, "Signed at Gen Con!", &Publisher{ID: 2, Name: "Coin Flip Games"}, &contractDate)
+	if err != nil {
+		t.Fatalf("Expected game to be signed, got %v", err)
+	}
+
+	if *g.PublisherID != 2 || *change.PublisherID != 2 || !change.ContractDate.Equal(contractDate) {
+		t.Errorf("Contract details not captured: %+v", change)
+	}
+
+	if GameStatusChanged(g, change).Data["publisher"] != "Coin Flip Games" {
+		t.Error("Expected the publisher's name to be passed on to designers")
+	}
+
+	_, err = g.ChangeStatus("Prototype", &User{ID: 1}, "Contract ended", nil, nil)
+	if err != nil {
+		t.Fatalf("Expected game to go back to prototype, got %v", err)
+	}
+
+	if g.Publisher != nil || g.PublisherID != nil || g.ContractDate != nil {
+		t.Errorf("Expected contract details to be cleared, got %v %v", g.PublisherID, g.ContractDate)
+	}
+}
+
 func TestAddDesigner(t *testing.T) {
 	var tests = []struct {
 		game              *Game
@@ -136,32 +181,6 @@ func TestAddDesigner(t *testing.T) {
 	}
 }
 
-func TestReplaceDesigners(t *testing.T) {
-	var tests = []struct {
-		game              *Game
-		designers         []User
-		expectedDesigners []uint
-	}{
-		{&Game{Designers: nil}, []User{User{ID: 1}}, []uint{1}},
-		{&Game{Designers: []User{}}, []User{User{ID: 1}}, []uint{1}},
-		{&Game{Designers: []User{User{ID: 1}}}, []User{User{ID: 2}}, []uint{2}},
-		{&Game{Designers: []User{User{ID: 1}}}, []User{User{ID: 1}}, []uint{1}},
-	}
-
-	for _, tt := range tests {
-		tt.game.ReplaceDesigners(tt.designers)
-
-		actualIDs := []uint{}
-		for _, u := range tt.game.Designers {
-			actualIDs = append(actualIDs, u.ID)
-		}
-
-		if !EqualUintSlice(actualIDs, tt.expectedDesigners) {
-			t.Errorf("Mismatched designers after replace")
-		}
-	}
-}
-
 func EqualUintSlice(a, b []uint) bool {
 	if len(a) != len(b) {
 		return false
@@ -173,3 +192,60 @@ func EqualUintSlice(a, b []uint) bool {
 	}
 	return true
 }
+
+func TestRulesSections(t *testing.T) {
+	g := &Game{ID: 1}
+
+	first, _ := g.AddRulesSection("Components", "52 cards")
+	if first.GameID != 1 || first.OrderBy != 0 {
+		t.Errorf("First section incorrect: %+v", first)
+	}
+
+	g.Rules[0].ID = 10
+	second, _ := g.AddRulesSection("Setup", "Shuffle")
+	if second.OrderBy != 1 {
+		t.Errorf("New sections should go at the end, got order %d", second.OrderBy)
+	}
+	g.Rules[1].ID = 11
+
+	if s, err := g.RulesSection(11); err != nil || s.Title != "Setup" {
+		t.Error("Expected to find the setup section")
+	}
+
+	if _, err := g.RulesSection(12); err == nil {
+		t.Error("Sections of other games shouldn't be found")
+	}
+
+	if err := g.ReorderRules([]uint{11, 10}); err != nil || g.Rules[0].ID != 11 || g.Rules[1].OrderBy != 1 {
+		t.Errorf("Rules should be reordered, got %+v", g.Rules)
+	}
+
+	removed, err := g.RemoveRulesSection(11)
+	if err != nil || removed.ID != 11 || len(g.Rules) != 1 || g.Rules[0].ID != 10 {
+		t.Errorf("Section should be removed, got %+v", g.Rules)
+	}
+
+	if _, err := g.RemoveRulesSection(11); err == nil {
+		t.Error("Sections can only be removed once")
+	}
+}
+
+func TestRulebook(t *testing.T) {
+	g := NewGame("First Game", User{ID: 123, Name: "Designer McDesignerton"})
+	g.UpdateOverview("In the First Game, players race to the finish")
+
+	book := g.Rulebook([]game.RulesSection{
+		{ID: 2, Title: "Scoring", OrderBy: 2},
+		{ID: 1, Title: "Components", OrderBy: 0},
+		{ID: 3, Title: "Setup", OrderBy: 1},
+	})
+
+	if book.Title != "First Game" || book.Overview == "" || book.Stats.MaxPlayers != 5 || len(book.Designers) != 1 || book.Designers[0] != "Designer McDesignerton" {
+		t.Errorf("Title page incorrect: %+v", book)
+	}
+
+	contents := book.Contents()
+	if len(contents) != 3 || contents[0] != "Components" || contents[1] != "Setup" || contents[2] != "Scoring" {
+		t.Errorf("Contents should follow the order of the rules, got %v", contents)
+	}
+}
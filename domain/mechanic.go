@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/lib/pq"
+)
+
+// Mechanic is a single entry in the taxonomy games are tagged with. Games are tagged by name, while synonyms
+// let designers find a mechanic by whatever they happen to call it. Mechanics may be grouped under a parent
+// category.
+type Mechanic struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+	UpdatedAt time.Time `json:"updated_at" example:"2020-12-13T15:42:40.578904-08:00"`
+
+	Slug        string         `json:"slug" gorm:"uniqueIndex;not null" example:"worker-placement"`
+	Name        string         `json:"name" gorm:"uniqueIndex;not null" example:"Worker Placement"`
+	Description string         `json:"description" example:"Players take turns placing workers on spaces to take actions"`
+	Synonyms    pq.StringArray `json:"synonyms" gorm:"type:text[]" example:"['Action Drafting']"`
+	ParentID    *uint          `json:"parent_id,omitempty" gorm:"index" example:"120"`
+}
+
+// MechanicRepository defines how to interact with the mechanics taxonomy in database
+type MechanicRepository interface {
+	AllMechanics() ([]Mechanic, error)
+	MechanicOfID(id uint) (*Mechanic, error)
+	MechanicUsage() (map[string]int, error)
+	Save(*Mechanic) error
+	Merge(target, source *Mechanic) error
+}
+
+// NewMechanic creates a new entry for the taxonomy. Its name and synonyms can't already be in use, and its
+// parent, if any, must be part of the taxonomy.
+func NewMechanic(taxonomy []Mechanic, name, description string, synonyms []string, parentID *uint) (*Mechanic, error) {
+	name = strings.TrimSpace(name)
+	if game.Slugify(name) == "" {
+		return nil, game.InvalidMechanic{Reason: "mechanics must be named"}
+	}
+
+	m := &Mechanic{
+		Slug:        game.Slugify(name),
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Synonyms:    pq.StringArray{},
+		ParentID:    parentID,
+	}
+
+	for _, synonym := range synonyms {
+		m.addSynonym(synonym)
+	}
+
+	for _, existing := range taxonomy {
+		for _, term := range append([]string{m.Name}, m.Synonyms...) {
+			if existing.Matches(term) {
+				return nil, game.InvalidMechanic{Reason: fmt.Sprintf("'%s' is already used by %s", term, existing.Name)}
+			}
+		}
+	}
+
+	if parentID != nil && findMechanic(taxonomy, *parentID) == nil {
+		return nil, game.InvalidMechanic{Reason: fmt.Sprintf("parent '%d' not found", *parentID)}
+	}
+
+	return m, nil
+}
+
+// Matches is true when the term is the mechanic's name, slug or one of its synonyms, ignoring case and punctuation
+func (m *Mechanic) Matches(term string) bool {
+	slug := game.Slugify(term)
+	if slug == "" {
+		return false
+	}
+
+	if slug == m.Slug || slug == game.Slugify(m.Name) {
+		return true
+	}
+
+	for _, synonym := range m.Synonyms {
+		if slug == game.Slugify(synonym) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Merge folds another mechanic into this one. The other mechanic's name and synonyms become synonyms of this
+// one, so games and searches using them still find their way here. Mechanics nested anywhere under the other
+// one take its place in the taxonomy, since its children are about to become theirs. Calling code is responsible
+// for retagging games and re-parenting the other mechanic's children.
+func (m *Mechanic) Merge(source *Mechanic, taxonomy []Mechanic) error {
+	if source.ID == m.ID {
+		return game.InvalidMechanic{Reason: "a mechanic can't be merged into itself"}
+	}
+
+	m.addSynonym(source.Name)
+	for _, synonym := range source.Synonyms {
+		m.addSynonym(synonym)
+	}
+
+	// Step out from under the mechanic that's going away, so its children don't end up above us
+	if m.descendsFrom(source.ID, taxonomy) {
+		m.ParentID = source.ParentID
+	}
+
+	return nil
+}
+
+// descendsFrom walks up the taxonomy from the mechanic, checking if the ancestor is on the way
+func (m *Mechanic) descendsFrom(ancestorID uint, taxonomy []Mechanic) bool {
+	visited := map[uint]bool{m.ID: true}
+	for parentID := m.ParentID; parentID != nil && !visited[*parentID]; {
+		if *parentID == ancestorID {
+			return true
+		}
+
+		visited[*parentID] = true
+
+		parent := findMechanic(taxonomy, *parentID)
+		if parent == nil {
+			return false
+		}
+
+		parentID = parent.ParentID
+	}
+
+	return false
+}
+
+// addSynonym keeps synonyms unique, and distinct from the mechanic's own name
+func (m *Mechanic) addSynonym(synonym string) {
+	synonym = strings.TrimSpace(synonym)
+	if synonym == "" || m.Matches(synonym) {
+		return
+	}
+
+	m.Synonyms = append(m.Synonyms, synonym)
+}
+
+// ResolveMechanics matches each of the provided names against the taxonomy, returning the canonical name of
+// each mechanic without duplicates. Names that don't match anything are rejected.
+func ResolveMechanics(taxonomy []Mechanic, names []string) ([]string, error) {
+	resolved := []string{}
+	unknown := []string{}
+	seen := map[uint]bool{}
+
+	for _, name := range names {
+		var match *Mechanic
+		for i := range taxonomy {
+			if taxonomy[i].Matches(name) {
+				match = &taxonomy[i]
+				break
+			}
+		}
+
+		if match == nil {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		if !seen[match.ID] {
+			seen[match.ID] = true
+			resolved = append(resolved, match.Name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, game.UnknownMechanics{Names: unknown}
+	}
+
+	return resolved, nil
+}
+
+func findMechanic(taxonomy []Mechanic, id uint) *Mechanic {
+	for i := range taxonomy {
+		if taxonomy[i].ID == id {
+			return &taxonomy[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/lib/pq"
+)
+
+func TestResolveMechanics(t *testing.T) {
+	parent := uint(1)
+	taxonomy := []Mechanic{
+		{ID: 1, Slug: "card-driven", Name: "Card Driven", Synonyms: pq.StringArray{}},
+		{ID: 2, Slug: "worker-placement", Name: "Worker Placement", Synonyms: pq.StringArray{"Action Drafting"}},
+		{ID: 3, Slug: "trick-taking", Name: "Trick-Taking", Synonyms: pq.StringArray{}, ParentID: &parent},
+	}
+
+	var tests = []struct {
+		names    []string
+		expected []string
+		unknown  []string
+	}{
+		{[]string{"worker placement", "Action Drafting", "trick taking"}, []string{"Worker Placement", "Trick-Taking"}, nil},
+		{[]string{"Card Driven", "Roll and Fight"}, nil, []string{"Roll and Fight"}},
+	}
+
+	for _, tt := range tests {
+		resolved, err := ResolveMechanics(taxonomy, tt.names)
+		if tt.unknown != nil {
+			unknown, ok := err.(game.UnknownMechanics)
+			if !ok || !reflect.DeepEqual(unknown.Names, tt.unknown) {
+				t.Errorf("Expected %v to be rejected, got %v", tt.unknown, err)
+			}
+
+			continue
+		}
+
+		if err != nil || !reflect.DeepEqual(resolved, tt.expected) {
+			t.Errorf("Expected canonical names %v without duplicates, got %v (%v)", tt.expected, resolved, err)
+		}
+	}
+}
+
+func TestNewMechanic(t *testing.T) {
+	parent, missing := uint(1), uint(99)
+	taxonomy := []Mechanic{
+		{ID: 1, Slug: "card-driven", Name: "Card Driven", Synonyms: pq.StringArray{}},
+		{ID: 2, Slug: "worker-placement", Name: "Worker Placement", Synonyms: pq.StringArray{"Action Drafting"}},
+	}
+
+	var tests = []struct {
+		name         string
+		synonyms     []string
+		parentID     *uint
+		expectedSlug string
+		expectedName string
+		synonymCount int
+	}{
+		{" Deck Building ", []string{"Deckbuilder", "deck building"}, &parent, "deck-building", "Deck Building", 1},
+		{"New Thing", []string{"action drafting"}, nil, "", "", 0}, // Synonym already in use
+		{"New Thing", nil, &missing, "", "", 0},                    // Missing parent
+		{" / ", nil, nil, "", "", 0},                               // Blank name
+	}
+
+	for _, tt := range tests {
+		m, err := NewMechanic(taxonomy, tt.name, "", tt.synonyms, tt.parentID)
+		if tt.expectedSlug == "" {
+			if err == nil {
+				t.Errorf("Expected %q to be rejected", tt.name)
+			}
+
+			continue
+		}
+
+		if err != nil || m.Slug != tt.expectedSlug || m.Name != tt.expectedName || len(m.Synonyms) != tt.synonymCount {
+			t.Errorf("Mechanic %q incorrect: %+v (%v)", tt.name, m, err)
+		}
+	}
+}
+
+func TestMergeMechanic(t *testing.T) {
+	parent := uint(1)
+	target := &Mechanic{ID: 3, Slug: "trick-taking", Name: "Trick-Taking", Synonyms: pq.StringArray{}, ParentID: &parent}
+	source := &Mechanic{ID: 4, Slug: "tricks", Name: "Tricks", Synonyms: pq.StringArray{"Trick Games"}, ParentID: &parent}
+	taxonomy := []Mechanic{{ID: 1, Slug: "card-driven", Name: "Card Driven"}, *target, *source}
+
+	if err := target.Merge(target, taxonomy); err == nil {
+		t.Error("Expected merging a mechanic into itself to fail")
+	}
+
+	if err := target.Merge(source, taxonomy); err != nil {
+		t.Fatalf("Expected merge to succeed, got %v", err)
+	}
+
+	if !target.Matches("tricks") || !target.Matches("Trick Games") || len(target.Synonyms) != 2 {
+		t.Errorf("Expected the source's name and synonyms to carry over, got %v", target.Synonyms)
+	}
+}
+
+func TestMergeMechanicIntoDescendant(t *testing.T) {
+	top, middle := uint(1), uint(2)
+
+	var tests = []struct {
+		source   uint
+		target   uint
+		expected *uint
+	}{
+		{1, 3, nil},     // The grandparent goes away, so the grandchild takes its place at the top
+		{2, 3, &top},    // The parent goes away, so the child moves up a level
+		{3, 1, nil},     // Merging upwards leaves the target where it is
+		{1, 4, nil},     // Unrelated mechanics stay put
+		{4, 3, &middle}, // Unrelated mechanics stay put
+	}
+
+	for _, tt := range tests {
+		taxonomy := []Mechanic{
+			{ID: 1, Slug: "card-driven", Name: "Card Driven"},
+			{ID: 2, Slug: "trick-taking", Name: "Trick-Taking", ParentID: &top},
+			{ID: 3, Slug: "plain-trick", Name: "Plain Trick", ParentID: &middle},
+			{ID: 4, Slug: "worker-placement", Name: "Worker Placement"},
+		}
+		source, target := findMechanic(taxonomy, tt.source), findMechanic(taxonomy, tt.target)
+
+		if err := target.Merge(source, taxonomy); err != nil {
+			t.Fatalf("Expected merge to succeed, got %v", err)
+		}
+
+		if (target.ParentID == nil) != (tt.expected == nil) || (target.ParentID != nil && *target.ParentID != *tt.expected) {
+			t.Errorf("Merging %d into %d: expected parent %v, got %v", tt.source, tt.target, tt.expected, target.ParentID)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// PlaytestUpdated is published whenever a playtest changes, so anyone watching the board for its date can refresh.
+// Playtests that moved also pass the dates they moved from, since those boards changed too.
+func PlaytestUpdated(p *Playtest, previousDates ...time.Time) DomainEvent {
+	var eventID uint
+	if p.EventID != nil {
+		eventID = *p.EventID
+	}
+
+	return DomainEvent{
+		Name: "Playtest/Updated",
+		Data: map[string]interface{}{
+			"playtestID": p.ID,
+			"eventID":    eventID,
+			"dates":      append([]time.Time{p.ScheduledDate}, previousDates...),
+		},
+	}
+}
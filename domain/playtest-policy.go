@@ -0,0 +1,32 @@
+package domain
+
+// MayBeManagedBy checks if the given user may run the playtest: assign its table, start it,
+// collect feedback and finish it. Designers of the game and facilitators of the event may do so.
+func (p *Playtest) MayBeManagedBy(user *User) bool {
+	if user == nil {
+		return false
+	}
+
+	if p.Game.MayBeUpdatedBy(user) {
+		return true
+	}
+
+	if p.Event != nil && p.Event.MayBeUpdatedBy(user) {
+		return true
+	}
+
+	return false
+}
+
+// MaySeeSecretsOf checks if the given user may see the playtest's location secrets, like the TTS password.
+// Designers of the game, players in the test and facilitators of the event may do so.
+func (p *Playtest) MaySeeSecretsOf(user *User) bool {
+	return p.MayBeManagedBy(user) || p.HasPlayer(user)
+}
+
+// RedactSecretsFor hides the playtest's location secrets unless the given user may see them
+func (p *Playtest) RedactSecretsFor(user *User) {
+	if p.Location != nil && !p.MaySeeSecretsOf(user) {
+		p.Location.Redact()
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+func TestPlaytestMayBeManagedBy(t *testing.T) {
+	game := Game{Designers: []User{User{ID: 1}}}
+	event := &Event{Facilitators: []User{User{ID: 2}}}
+
+	var tests = []struct {
+		playtest      *Playtest
+		user          *User
+		expectAllowed bool
+	}{
+		{&Playtest{Game: game, Event: event}, &User{ID: 1}, true},
+		{&Playtest{Game: game, Event: event}, &User{ID: 2}, true},
+		{&Playtest{Game: game, Event: event}, &User{ID: 3}, false},
+		{&Playtest{Game: game}, &User{ID: 2}, false},
+		{&Playtest{Game: game, Event: event}, nil, false},
+	}
+
+	for _, tt := range tests {
+		actual := tt.playtest.MayBeManagedBy(tt.user)
+		if tt.expectAllowed != actual {
+			t.Errorf("Managing permission incorrect for user %v", tt.user)
+		}
+	}
+}
+
+func TestPlaytestRedactSecretsFor(t *testing.T) {
+	game := Game{Designers: []User{User{ID: 1}}}
+	event := &Event{Facilitators: []User{User{ID: 2}}}
+
+	var tests = []struct {
+		user           *User
+		expectRedacted bool
+	}{
+		{&User{ID: 1}, false},
+		{&User{ID: 2}, false},
+		{&User{ID: 3}, false},
+		{&User{ID: 4}, true},
+		{nil, true},
+	}
+
+	for _, tt := range tests {
+		p := &Playtest{
+			Game:     game,
+			Event:    event,
+			Players:  []User{User{ID: 3}},
+			Location: &playtest.Location{TTSServer: "server", TTSPassword: "hunter2"},
+		}
+
+		p.RedactSecretsFor(tt.user)
+		if redacted := p.Location.TTSPassword == playtest.Redacted; redacted != tt.expectRedacted {
+			t.Errorf("Password redaction incorrect for user %v", tt.user)
+		}
+
+		if p.Location.TTSServer != "server" {
+			t.Error("Server name should never be redacted")
+		}
+	}
+}
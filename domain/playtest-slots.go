@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// PlaytestSlot gathers the playtests running in the same slot of an event occurrence
+type PlaytestSlot struct {
+	EventID   uint       `json:"event,omitempty" example:"123"`
+	Slot      uint       `json:"slot" example:"1"`
+	StartsAt  time.Time  `json:"starts_at" example:"2020-12-16T18:00:00-08:00"`
+	Playtests []Playtest `json:"playtests"`
+}
+
+// GroupPlaytestsBySlot groups playtests by event and slot, earliest slot first. Playtests that aren't
+// part of an event end up together in slot 0.
+func GroupPlaytestsBySlot(playtests []Playtest) []PlaytestSlot {
+	type key struct {
+		eventID uint
+		slot    uint
+	}
+
+	groups := map[key]*PlaytestSlot{}
+	order := []key{}
+
+	for _, p := range playtests {
+		k := key{slot: p.Slot}
+		if p.EventID != nil {
+			k.eventID = *p.EventID
+		}
+
+		group, ok := groups[k]
+		if !ok {
+			group = &PlaytestSlot{EventID: k.eventID, Slot: k.slot, StartsAt: p.ScheduledDate, Playtests: []Playtest{}}
+			groups[k] = group
+			order = append(order, k)
+		}
+
+		// Table assignments can push tests later within a slot, so the slot starts with its earliest test
+		if p.ScheduledDate.Before(group.StartsAt) {
+			group.StartsAt = p.ScheduledDate
+		}
+
+		group.Playtests = append(group.Playtests, p)
+	}
+
+	slots := make([]PlaytestSlot, 0, len(order))
+	for _, k := range order {
+		slots = append(slots, *groups[k])
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		if !slots[i].StartsAt.Equal(slots[j].StartsAt) {
+			return slots[i].StartsAt.Before(slots[j].StartsAt)
+		}
+
+		return slots[i].Slot < slots[j].Slot
+	})
+
+	return slots
+}
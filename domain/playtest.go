@@ -15,36 +15,70 @@ type Playtest struct {
 	UpdatedAt time.Time      `json:"updated_at" example:"2020-12-13T15:42:40.578904-08:00"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Game   Game `json:"game"`
-	GameID uint `json:"-"`
+	Game          Game         `json:"game"`
+	GameID        uint         `json:"-"`
+	GameVersion   *GameVersion `json:"game_version,omitempty"`
+	GameVersionID *uint        `json:"-" gorm:"index"`
 
 	Event         *Event    `json:"-"`
 	EventID       *uint     `json:"-"`
-	ScheduledDate time.Time `json:"-"`
+	ScheduledDate time.Time `json:"scheduled_date" example:"2020-12-16T18:00:00-08:00"`
+	Slot          uint      `json:"slot,omitempty" example:"1"`
 
+	State        playtest.State        `json:"state" example:"Registered"`
 	Requirements playtest.Requirements `json:"requirements" gorm:"embedded"`
 	Location     *playtest.Location    `json:"location,omitempty" gorm:"embedded"`
 	StartTime    sql.NullTime          `json:"start_time"`
 	FeedbackTime sql.NullTime          `json:"feedback_time"`
 	EndTime      sql.NullTime          `json:"end_time"`
+	CancelTime   sql.NullTime          `json:"cancel_time"`
 	Players      []User                `json:"players" gorm:"many2many:playtesters;"`
+	Waitlist     []WaitlistEntry       `json:"waitlist"`
+	Attendance   []AttendanceRecord    `json:"attendance,omitempty"`
+	Ending       playtest.Ending       `json:"ending,omitempty" example:"Completed"`
+	Results      []SeatResult          `json:"results,omitempty"`
+
+	CancellationReason string       `json:"cancellation_reason,omitempty" example:"The designer is unwell"`
+	RescheduledFrom    sql.NullTime `json:"rescheduled_from"`
+	RescheduleReason   string       `json:"reschedule_reason,omitempty" example:"Moved to make room for a tournament"`
 }
 
 // PlaytestRepository defines how to interact with playtests in database
 type PlaytestRepository interface {
-	PlaytestsOnDate(time.Time, uint) ([]Playtest, error)
+	PlaytestsBetween(start, end time.Time, eventID uint, includeCancelled bool) ([]Playtest, error)
+	PlaytestsOfGame(gameID uint, from, to time.Time, eventID, versionID uint, limit, offset int) ([]Playtest, int, error)
+	HistoryOfGame(gameID uint, from, to time.Time, eventID, versionID uint) (playtest.HistoryMetrics, error)
 	PlaytestOfID(id uint) (*Playtest, error)
+	AttendanceOfUsers(userIDs []uint) ([]AttendanceRecord, error)
+	Register(p *Playtest, snapshot *GameVersion, registrantID uint, charge func(balance int) (*CreditTransaction, error)) error
 	Save(*Playtest) error
+	SaveAll([]Playtest) error
+	SaveWithCredits(p *Playtest, transactions []CreditTransaction) error
+	EncryptSecrets() (int, error)
 }
 
-// RegisterGame sets up a new playtest for a game at a specific time. It can optionally be tied to an event
-func RegisterGame(game *Game, event *Event, sched time.Time, minPlayers, maxPlayers, duration uint, designerWantsToPlay bool, hopeToTest, ttsServer, ttsPassword string) *Playtest {
-	sched = sched.Truncate(time.Hour * 24) // We only want the date
+// RegisterGame sets up a new playtest for a game on a specific date. It can optionally be tied to an event,
+// in which case the playtest starts at the requested slot of the event's occurrences that day. Slot 0
+// picks the first slot of the day. The playtest is pinned to the game's current version, if it has one; when
+// the game has changed since, it's pinned to a new snapshot as it's registered.
+func RegisterGame(game *Game, event *Event, date time.Time, slot uint, minPlayers, maxPlayers, duration uint, designerWantsToPlay bool, hopeToTest, ttsServer, ttsPassword string) (*Playtest, error) {
+	sched, slot, err := scheduleAt(event, date, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventID *uint
+	if event != nil {
+		eventID = &event.ID
+	}
 
 	return &Playtest{
 		GameID:        game.ID,
-		EventID:       &event.ID,
+		GameVersionID: game.CurrentVersionID,
+		EventID:       eventID,
 		ScheduledDate: sched,
+		Slot:          slot,
+		State:         playtest.Registered,
 		Requirements: playtest.Requirements{
 			MinPlayers:          minPlayers,
 			MaxPlayers:          maxPlayers,
@@ -54,13 +88,26 @@ func RegisterGame(game *Game, event *Event, sched time.Time, minPlayers, maxPlay
 		},
 		Location: &playtest.Location{
 			TTSServer:   ttsServer,
-			TTSPassword: ttsPassword,
+			TTSPassword: playtest.Secret(ttsPassword),
 		},
+	}, nil
+}
+
+// AfterFind hook for filling in the state of playtests created before states were tracked
+func (p *Playtest) AfterFind(tx *gorm.DB) (err error) {
+	if p.State == "" {
+		p.State = p.inferState()
 	}
+
+	return nil
 }
 
 // AssignTable will place the playtest at a table (real or virtual)
-func (p *Playtest) AssignTable(table string) {
+func (p *Playtest) AssignTable(table string) error {
+	if err := p.transition(playtest.Seated); err != nil {
+		return err
+	}
+
 	if p.Location == nil {
 		p.Location = &playtest.Location{
 			Table: table,
@@ -68,50 +115,275 @@ func (p *Playtest) AssignTable(table string) {
 	} else {
 		p.Location.Table = table
 	}
+
+	return nil
+}
+
+// AssignTableAt will place the playtest at a table, starting at a particular time on its scheduled day
+func (p *Playtest) AssignTableAt(table string, startsAt time.Time) error {
+	if err := p.AssignTable(table); err != nil {
+		return err
+	}
+
+	if !startsAt.IsZero() {
+		p.ScheduledDate = startsAt
+	}
+
+	return nil
 }
 
-// Start will set the time the playtest started to now
-func (p *Playtest) Start() {
+// RotateTTSPassword replaces the password for the playtest's Tabletop Simulator server
+func (p *Playtest) RotateTTSPassword(password playtest.Secret) {
+	if p.Location == nil {
+		p.Location = &playtest.Location{}
+	}
+
+	p.Location.TTSPassword = password
+}
+
+// Start will set the time the playtest started to now and check in the players who showed up.
+// Players missing from attendees are marked as no-shows; nil attendees checks in every player.
+func (p *Playtest) Start(attendees []uint) error {
+	if err := p.transition(playtest.InProgress); err != nil {
+		return err
+	}
+
 	p.StartTime = sql.NullTime{Time: time.Now(), Valid: true}
+	p.checkIn(attendees)
+
+	return nil
 }
 
 // StartFeedback will set the time feedback started to now
-func (p *Playtest) StartFeedback() {
+func (p *Playtest) StartFeedback() error {
+	if err := p.transition(playtest.Feedback); err != nil {
+		return err
+	}
+
 	p.FeedbackTime = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return nil
 }
 
 // Finish will set the time the playtest ended to now
-func (p *Playtest) Finish() {
+func (p *Playtest) Finish() error {
+	if err := p.transition(playtest.Finished); err != nil {
+		return err
+	}
+
 	p.EndTime = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return nil
 }
 
-// AddPlayer adds a new player to the test
-func (p *Playtest) AddPlayer(player *User) {
-	if player == nil {
-		return
+// Cancel calls off a playtest that hasn't started yet. The playtest is kept, along with the reason.
+func (p *Playtest) Cancel(reason string) error {
+	if err := p.transition(playtest.Cancelled); err != nil {
+		return err
 	}
 
-	if p.Players == nil {
-		p.Players = []User{}
+	p.CancelTime = sql.NullTime{Time: time.Now(), Valid: true}
+	p.CancellationReason = reason
+
+	return nil
+}
+
+// Reschedule moves a playtest that hasn't started yet to another date, and slot if it's part of an event.
+// Its table is given up, since it was only booked for the original time, so seated playtests go back
+// to being registered. The original date is kept, along with the reason. Playtests can't move to a day
+// that has already passed.
+func (p *Playtest) Reschedule(date time.Time, slot uint, reason string) error {
+	if !p.State.CanTransitionTo(playtest.Registered) {
+		return playtest.InvalidTransition{From: p.State, To: playtest.Registered}
+	}
+
+	sched, slot, err := scheduleAt(p.Event, date, slot)
+	if err != nil {
+		return err
+	}
+
+	if sched.Before(time.Now().Truncate(24 * time.Hour)) {
+		return playtest.DateInPast{Date: date}
+	}
+
+	if !p.RescheduledFrom.Valid {
+		p.RescheduledFrom = sql.NullTime{Time: p.ScheduledDate, Valid: true}
+	}
+
+	p.ScheduledDate = sched
+	p.Slot = slot
+	p.RescheduleReason = reason
+
+	if p.Location != nil {
+		p.Location.Table = ""
+	}
+
+	return p.transition(playtest.Registered)
+}
+
+// AcceptingFeedback checks if players may leave feedback yet
+func (p *Playtest) AcceptingFeedback() bool {
+	return p.State == playtest.Feedback || p.State == playtest.Finished
+}
+
+// HasPlayer checks if the given user is playing in this test
+func (p *Playtest) HasPlayer(player *User) bool {
+	if player == nil {
+		return false
 	}
 
 	for _, u := range p.Players {
 		if u.ID == player.ID {
-			return
+			return true
 		}
 	}
 
+	return false
+}
+
+// AddPlayer adds a new player to the test. If the test is already full, the player is added to the
+// end of the waitlist instead and true is returned. Tests that have started or been cancelled turn everyone away.
+func (p *Playtest) AddPlayer(player *User) (bool, error) {
+	if player == nil {
+		return false, nil
+	}
+
+	if !p.acceptingPlayers() {
+		return false, playtest.NotAcceptingPlayers{State: p.State}
+	}
+
+	if p.Players == nil {
+		p.Players = []User{}
+	}
+
+	if p.HasPlayer(player) {
+		return false, nil
+	}
+
+	if p.IsFull() || p.IsWaitlisted(player) {
+		p.joinWaitlist(player)
+		return true, nil
+	}
+
 	p.Players = append(p.Players, *player)
+
+	return false, nil
 }
 
-// RemovePlayer removes the specified player from the test
-func (p *Playtest) RemovePlayer(player *User) {
+// RemovePlayer removes the specified player from the test, or from the waitlist if they were still waiting.
+// Whenever a seat opens up, the next user on the waitlist takes it and is returned so they can be notified.
+func (p *Playtest) RemovePlayer(player *User) *User {
+	if player == nil {
+		return nil
+	}
+
+	if p.IsWaitlisted(player) {
+		p.leaveWaitlist(player)
+		return nil
+	}
+
 	for i, u := range p.Players {
 		if u.ID == player.ID {
 			copy(p.Players[i:], p.Players[i+1:])
 			p.Players = p.Players[:len(p.Players)-1]
 
-			return
+			return p.promoteFromWaitlist()
 		}
 	}
+
+	return nil
+}
+
+// acceptingPlayers checks if the playtest is still open to new players
+func (p *Playtest) acceptingPlayers() bool {
+	return p.State == playtest.Registered || p.State == playtest.Seated
+}
+
+// PlaytestCancelled lets the players know the playtest was called off
+func PlaytestCancelled(p *Playtest) DomainEvent {
+	return DomainEvent{
+		Name: "Playtest/Cancelled",
+		Data: map[string]interface{}{
+			"playtestID": p.ID,
+			"game":       p.Game.Title,
+			"date":       p.ScheduledDate,
+			"reason":     p.CancellationReason,
+			"players":    p.recipients(),
+		},
+	}
+}
+
+// PlaytestRescheduled lets the players know the playtest moved from the previous date
+func PlaytestRescheduled(p *Playtest, previous time.Time) DomainEvent {
+	return DomainEvent{
+		Name: "Playtest/Rescheduled",
+		Data: map[string]interface{}{
+			"playtestID": p.ID,
+			"game":       p.Game.Title,
+			"from":       previous,
+			"date":       p.ScheduledDate,
+			"reason":     p.RescheduleReason,
+			"players":    p.recipients(),
+		},
+	}
+}
+
+// recipients lists the name and email of every player, for notifying them about changes to the playtest
+func (p *Playtest) recipients() []map[string]string {
+	recipients := []map[string]string{}
+	for _, player := range p.Players {
+		recipients = append(recipients, map[string]string{
+			"name":  player.Name,
+			"email": player.Account.Email,
+		})
+	}
+
+	return recipients
+}
+
+// scheduleAt works out when a playtest on the given date starts. Playtests at an event start at the
+// requested slot of the event's occurrences that day, with slot 0 picking the first. Without an event,
+// we only want the date.
+func scheduleAt(event *Event, date time.Time, slot uint) (time.Time, uint, error) {
+	if event == nil {
+		return date.Truncate(time.Hour * 24), 0, nil
+	}
+
+	if slot == 0 {
+		slot = 1
+	}
+
+	s, err := event.SlotOn(date, slot)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return s.StartsAt, slot, nil
+}
+
+// transition moves the playtest to the next state in its lifecycle, provided the move is allowed
+func (p *Playtest) transition(next playtest.State) error {
+	if !p.State.CanTransitionTo(next) {
+		return playtest.InvalidTransition{From: p.State, To: next}
+	}
+
+	p.State = next
+
+	return nil
+}
+
+// inferState works out the state of a playtest from its timestamps and location
+func (p *Playtest) inferState() playtest.State {
+	switch {
+	case p.EndTime.Valid:
+		return playtest.Finished
+	case p.FeedbackTime.Valid:
+		return playtest.Feedback
+	case p.StartTime.Valid:
+		return playtest.InProgress
+	case p.Location != nil && p.Location.Table != "":
+		return playtest.Seated
+	default:
+		return playtest.Registered
+	}
 }
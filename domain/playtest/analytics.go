@@ -0,0 +1,57 @@
+package playtest
+
+// Analytics summarize how balanced a game is, based on the outcomes recorded for its playtests
+type Analytics struct {
+	Games                int                  `json:"games" example:"24"`
+	SeatWinRates         []SeatWinRate        `json:"seat_win_rates"`
+	PlayerCounts         []PlayerCountWinRate `json:"player_counts"`
+	ScoreSpread          ScoreSpread          `json:"score_spread"`
+	FirstPlayerAdvantage FirstPlayerAdvantage `json:"first_player_advantage"`
+	Trend                []TrendPoint         `json:"trend"`
+}
+
+// SeatWinRate is how often the player in a particular seat wins
+type SeatWinRate struct {
+	Seat    uint    `json:"seat" example:"1"`
+	Games   int     `json:"games" example:"24"`
+	Wins    int     `json:"wins" example:"9"`
+	WinRate float64 `json:"win_rate" example:"0.375"`
+}
+
+// PlayerCountWinRate breaks down seat win rates for games with a particular number of players
+type PlayerCountWinRate struct {
+	Players      int           `json:"players" example:"4"`
+	Games        int           `json:"games" example:"10"`
+	SeatWinRates []SeatWinRate `json:"seat_win_rates"`
+}
+
+// ScoreSpread describes how final scores are distributed. Margin is the gap between the highest and
+// lowest score in a single game.
+type ScoreSpread struct {
+	Scores        int     `json:"scores" example:"80"`
+	Min           int     `json:"min" example:"12"`
+	Max           int     `json:"max" example:"97"`
+	Mean          float64 `json:"mean" example:"54.2"`
+	StdDev        float64 `json:"std_dev" example:"14.8"`
+	AverageMargin float64 `json:"average_margin" example:"21.5"`
+}
+
+// FirstPlayerAdvantage compares how often the first player wins against how often they'd win if every seat
+// were equal. The interval is a 95% Wilson score interval around the first player's win rate.
+type FirstPlayerAdvantage struct {
+	Games           int     `json:"games" example:"24"`
+	WinRate         float64 `json:"win_rate" example:"0.375"`
+	ExpectedWinRate float64 `json:"expected_win_rate" example:"0.27"`
+	Advantage       float64 `json:"advantage" example:"0.105"`
+	LowerBound      float64 `json:"lower_bound" example:"0.21"`
+	UpperBound      float64 `json:"upper_bound" example:"0.57"`
+}
+
+// TrendPoint summarizes the games played during a single period, so balance changes can be tracked over time
+type TrendPoint struct {
+	Period             string  `json:"period" example:"2020-12"`
+	Games              int     `json:"games" example:"6"`
+	FirstPlayerWinRate float64 `json:"first_player_win_rate" example:"0.33"`
+	AverageMargin      float64 `json:"average_margin" example:"18.5"`
+	AbandonedRate      float64 `json:"abandoned_rate" example:"0.1"`
+}
@@ -0,0 +1,29 @@
+package playtest
+
+// Attendance records whether a player showed up for a playtest they joined
+type Attendance string
+
+const (
+	// Attended players were checked in when the playtest started
+	Attended Attendance = "Attended"
+
+	// NoShow players joined but weren't there when the playtest started
+	NoShow = "No-show"
+)
+
+// LowReliability is the default score below which players are flagged to facilitators
+const LowReliability = 0.75
+
+// Reliability summarizes how often a user shows up for the playtests they join
+type Reliability struct {
+	UserID   uint    `json:"user" example:"123"`
+	Attended int     `json:"attended" example:"9"`
+	NoShows  int     `json:"no_shows" example:"1"`
+	Score    float64 `json:"score" example:"0.9"`
+}
+
+// IsBelow checks if the user has missed enough playtests to fall below the threshold.
+// Users without any history are given the benefit of the doubt.
+func (r Reliability) IsBelow(threshold float64) bool {
+	return r.Attended+r.NoShows > 0 && r.Score < threshold
+}
@@ -0,0 +1,10 @@
+package playtest
+
+// HistoryMetrics aggregate how a game has fared across all of its playtests
+type HistoryMetrics struct {
+	Tests                    int         `json:"tests" example:"12"`
+	DistinctTesters          int         `json:"distinct_testers" example:"31"`
+	AverageDuration          float64     `json:"average_duration" example:"72.5"`
+	AverageRequestedDuration float64     `json:"average_requested_duration" example:"60"`
+	PlayerCounts             map[int]int `json:"player_counts" example:"3:4,4:6,5:2"`
+}
@@ -4,5 +4,12 @@ package playtest
 type Location struct {
 	Table       string `json:"table,omitempty"`
 	TTSServer   string `json:"tts_server,omitempty"`
-	TTSPassword string `json:"tts_password,omitempty"`
+	TTSPassword Secret `json:"tts_password,omitempty"`
+}
+
+// Redact hides the location's secrets, leaving the rest of the details in place
+func (l *Location) Redact() {
+	if l.TTSPassword != "" {
+		l.TTSPassword = Redacted
+	}
 }
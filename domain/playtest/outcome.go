@@ -0,0 +1,35 @@
+package playtest
+
+import "fmt"
+
+// Ending describes how a playtest's game came to an end
+type Ending string
+
+const (
+	// Completed games were played through to the end
+	Completed Ending = "Completed"
+
+	// Abandoned games were called off partway through
+	Abandoned = "Abandoned"
+)
+
+// EndingFromString returns the Ending corresponding to the provided string. Blank endings are assumed complete.
+func EndingFromString(s string) (Ending, error) {
+	switch s {
+	case "", "Completed":
+		return Completed, nil
+	case "Abandoned":
+		return Abandoned, nil
+	default:
+		return "", InvalidOutcome{Reason: fmt.Sprintf("unknown ending '%s'", s)}
+	}
+}
+
+// InvalidOutcome returned when the results recorded for a playtest don't add up
+type InvalidOutcome struct {
+	Reason string
+}
+
+func (e InvalidOutcome) Error() string {
+	return fmt.Sprintf("invalid outcome: %s", e.Reason)
+}
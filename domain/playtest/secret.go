@@ -0,0 +1,67 @@
+package playtest
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/secret"
+)
+
+// Redacted replaces secrets shown to people who aren't taking part in a playtest
+const Redacted Secret = "********"
+
+// passwordAlphabet leaves out characters that are easily confused when read aloud or typed into TTS
+const passwordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Secret is a string that is encrypted at rest
+type Secret string
+
+// GeneratePassword creates a random password suitable for a Tabletop Simulator server
+func GeneratePassword(length int) (Secret, error) {
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordAlphabet))))
+		if err != nil {
+			return "", err
+		}
+
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+
+	return Secret(password), nil
+}
+
+// Value encrypts the secret for storage
+func (s Secret) Value() (driver.Value, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	return secret.Instance.Encrypt(string(s))
+}
+
+// Scan decrypts the stored secret
+func (s *Secret) Scan(value interface{}) error {
+	var stored string
+	switch v := value.(type) {
+	case nil:
+		stored = ""
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a secret", value)
+	}
+
+	plaintext, err := secret.Instance.Decrypt(stored)
+	if err != nil {
+		return err
+	}
+
+	*s = Secret(plaintext)
+
+	return nil
+}
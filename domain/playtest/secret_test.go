@@ -0,0 +1,46 @@
+package playtest
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/secret"
+)
+
+func TestSecretRoundTrip(t *testing.T) {
+	cipher, err := secret.NewCipher("test-key")
+	if err != nil {
+		t.Fatalf("Unexpected error creating cipher: %s", err)
+	}
+	secret.Instance = cipher
+
+	stored, err := Secret("hunter2").Value()
+	if err != nil {
+		t.Fatalf("Unexpected error encrypting secret: %s", err)
+	}
+
+	if stored == "hunter2" {
+		t.Error("Secrets should not be stored in plain text")
+	}
+
+	var s Secret
+	if err := s.Scan(stored); err != nil || s != "hunter2" {
+		t.Errorf("Expected secret to decrypt to 'hunter2', got '%s' (%v)", s, err)
+	}
+
+	// Passwords saved before encryption was introduced are still readable
+	if err := s.Scan("legacy"); err != nil || s != "legacy" {
+		t.Errorf("Expected unencrypted secret to pass through, got '%s' (%v)", s, err)
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	a, err := GeneratePassword(12)
+	if err != nil || len(a) != 12 {
+		t.Fatalf("Expected a 12 character password, got '%s' (%v)", a, err)
+	}
+
+	b, _ := GeneratePassword(12)
+	if a == b {
+		t.Error("Generated passwords should be random")
+	}
+}
@@ -0,0 +1,78 @@
+package playtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// State tracks where a playtest is in its lifecycle
+type State string
+
+const (
+	// Registered playtests have been signed up for, but not yet placed at a table
+	Registered State = "Registered"
+
+	// Seated playtests have a table (real or virtual) and are waiting to begin
+	Seated State = "Seated"
+
+	// InProgress playtests are being played right now
+	InProgress State = "InProgress"
+
+	// Feedback playtests are done playing and players are giving feedback
+	Feedback State = "Feedback"
+
+	// Finished playtests are complete
+	Finished State = "Finished"
+
+	// Cancelled playtests were called off before they began
+	Cancelled State = "Cancelled"
+)
+
+// transitions lists every state a playtest may move to from its current state
+var transitions = map[State][]State{
+	Registered: {Registered, Seated, Cancelled},
+	Seated:     {Registered, Seated, InProgress, Cancelled},
+	InProgress: {Feedback},
+	Feedback:   {Finished},
+	Finished:   {},
+	Cancelled:  {},
+}
+
+// CanTransitionTo checks if moving from this state to the next one is allowed
+func (s State) CanTransitionTo(next State) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InvalidTransition returned when a playtest is asked to skip or revisit a stage in its lifecycle
+type InvalidTransition struct {
+	From State
+	To   State
+}
+
+func (e InvalidTransition) Error() string {
+	return fmt.Sprintf("playtest cannot move from '%s' to '%s'", e.From, e.To)
+}
+
+// DateInPast returned when a playtest is asked to move to a day that has already gone by
+type DateInPast struct {
+	Date time.Time
+}
+
+func (e DateInPast) Error() string {
+	return fmt.Sprintf("playtests cannot be moved to %s, which has already passed", e.Date.Format("2006-01-02"))
+}
+
+// NotAcceptingPlayers returned when someone tries to join a playtest that has already started or been called off
+type NotAcceptingPlayers struct {
+	State State
+}
+
+func (e NotAcceptingPlayers) Error() string {
+	return fmt.Sprintf("players can no longer join a playtest that is '%s'", e.State)
+}
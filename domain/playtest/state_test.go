@@ -0,0 +1,30 @@
+package playtest
+
+import "testing"
+
+func TestCanTransitionTo(t *testing.T) {
+	var tests = []struct {
+		from          State
+		to            State
+		expectAllowed bool
+	}{
+		{Registered, Seated, true},
+		{Registered, InProgress, false},
+		{Registered, Cancelled, true},
+		{Seated, Seated, true},
+		{Seated, Registered, true},
+		{Seated, InProgress, true},
+		{InProgress, Feedback, true},
+		{InProgress, Cancelled, false},
+		{Feedback, Finished, true},
+		{Finished, InProgress, false},
+		{Cancelled, Registered, false},
+	}
+
+	for _, tt := range tests {
+		actual := tt.from.CanTransitionTo(tt.to)
+		if actual != tt.expectAllowed {
+			t.Errorf("Transition from '%s' to '%s' should be allowed=%v", tt.from, tt.to, tt.expectAllowed)
+		}
+	}
+}
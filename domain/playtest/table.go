@@ -0,0 +1,40 @@
+package playtest
+
+import "time"
+
+// Table is somewhere a playtest can happen: a physical table or a virtual Tabletop Simulator server
+type Table struct {
+	Name    string `json:"name" example:"Table 1"`
+	Seats   uint   `json:"seats" example:"6"`
+	Virtual bool   `json:"virtual" example:"false"`
+}
+
+// Assignment places a playtest at a table for a stretch of time
+type Assignment struct {
+	PlaytestID uint      `json:"playtest" example:"123"`
+	Table      string    `json:"table" example:"Table 1"`
+	StartsAt   time.Time `json:"starts_at" example:"2020-12-16T19:00:00-08:00"`
+	EndsAt     time.Time `json:"ends_at" example:"2020-12-16T20:00:00-08:00"`
+}
+
+// Unassigned explains why a playtest couldn't be given a table
+type Unassigned struct {
+	PlaytestID uint   `json:"playtest" example:"123"`
+	Reason     string `json:"reason" example:"no table has enough seats"`
+}
+
+// Plan is a proposed set of table assignments for an event day. Facilitators can tweak it before committing.
+type Plan struct {
+	Assignments []Assignment `json:"assignments"`
+	Unassigned  []Unassigned `json:"unassigned"`
+}
+
+// Overlaps checks if two assignments happen at the same time
+func (a Assignment) Overlaps(b Assignment) bool {
+	return a.StartsAt.Before(b.EndsAt) && b.StartsAt.Before(a.EndsAt)
+}
+
+// Fits checks if the table has enough seats for a playtest. Tables without a seat count fit anything.
+func (t Table) Fits(players uint) bool {
+	return t.Seats == 0 || t.Seats >= players
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/user"
+)
+
+func TestPlaytestLifecycle(t *testing.T) {
+	p := &Playtest{State: playtest.Registered}
+
+	if err := p.Start(nil); err == nil {
+		t.Error("Playtests must be seated before they start")
+	} else if _, ok := err.(playtest.InvalidTransition); !ok {
+		t.Errorf("Expected InvalidTransition error, got '%v'", err)
+	}
+
+	if err := p.AssignTable("1"); err != nil || p.State != playtest.Seated || p.Location.Table != "1" {
+		t.Error("Assigning a table should seat the playtest")
+	}
+
+	if err := p.Start(nil); err != nil || p.State != playtest.InProgress || !p.StartTime.Valid {
+		t.Error("Seated playtests should be able to start")
+	}
+
+	if err := p.Finish(); err == nil {
+		t.Error("Playtests must collect feedback before they finish")
+	}
+
+	if err := p.StartFeedback(); err != nil || p.State != playtest.Feedback || !p.FeedbackTime.Valid {
+		t.Error("In progress playtests should be able to start feedback")
+	}
+
+	if err := p.Finish(); err != nil || p.State != playtest.Finished || !p.EndTime.Valid {
+		t.Error("Playtests in feedback should be able to finish")
+	}
+
+	if err := p.Cancel("Too late"); err == nil {
+		t.Error("Finished playtests can't be cancelled")
+	}
+}
+
+func TestInferState(t *testing.T) {
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+
+	var tests = []struct {
+		playtest      *Playtest
+		expectedState playtest.State
+	}{
+		{&Playtest{}, playtest.Registered},
+		{&Playtest{Location: &playtest.Location{Table: "4"}}, playtest.Seated},
+		{&Playtest{StartTime: now}, playtest.InProgress},
+		{&Playtest{StartTime: now, FeedbackTime: now}, playtest.Feedback},
+		{&Playtest{StartTime: now, FeedbackTime: now, EndTime: now}, playtest.Finished},
+	}
+
+	for _, tt := range tests {
+		tt.playtest.AfterFind(nil)
+		if tt.playtest.State != tt.expectedState {
+			t.Errorf("Expected state '%s', got '%s'", tt.expectedState, tt.playtest.State)
+		}
+	}
+}
+
+func TestPlaytestWaitlist(t *testing.T) {
+	p := &Playtest{State: playtest.Registered, Requirements: playtest.Requirements{MaxPlayers: 2}}
+
+	for _, id := range []uint{1, 2} {
+		if waitlisted, err := p.AddPlayer(&User{ID: id}); waitlisted || err != nil {
+			t.Errorf("Player %d should be seated while there's room (%v)", id, err)
+		}
+	}
+
+	for _, id := range []uint{3, 4} {
+		if waitlisted, err := p.AddPlayer(&User{ID: id}); !waitlisted || err != nil {
+			t.Errorf("Player %d should be waitlisted once the playtest is full (%v)", id, err)
+		}
+	}
+
+	if len(p.Players) != 2 || len(p.Waitlist) != 2 || p.Waitlist[1].Position != 2 {
+		t.Errorf("Unexpected seating after filling up: %d players, %d waitlisted", len(p.Players), len(p.Waitlist))
+	}
+
+	promoted := p.RemovePlayer(&User{ID: 1})
+	if promoted == nil || promoted.ID != 3 || !p.HasPlayer(&User{ID: 3}) || p.IsWaitlisted(&User{ID: 3}) {
+		t.Error("Next waitlisted user should be promoted when a player leaves")
+	}
+
+	if len(p.Waitlist) != 1 || p.Waitlist[0].UserID != 4 || p.Waitlist[0].Position != 1 {
+		t.Error("Waitlist should move up after a promotion")
+	}
+
+	if promoted := p.RemovePlayer(&User{ID: 4}); promoted != nil || len(p.Waitlist) != 0 || len(p.Players) != 2 {
+		t.Error("Waitlisted users should be able to leave the waitlist")
+	}
+
+	p.State = playtest.InProgress
+	if _, err := p.AddPlayer(&User{ID: 5}); err != (playtest.NotAcceptingPlayers{State: playtest.InProgress}) {
+		t.Errorf("Expected playtests in progress to turn players away, got %v", err)
+	}
+}
+
+func TestCancelPlaytest(t *testing.T) {
+	p := &Playtest{
+		ID:      1,
+		State:   playtest.Seated,
+		Game:    Game{Title: "The Best Game"},
+		Players: []User{{ID: 2, Name: "Player", Account: user.Account{Email: "player@example.com"}}},
+	}
+
+	if err := p.Cancel("The designer is unwell"); err != nil {
+		t.Fatalf("Unexpected error cancelling playtest: %s", err)
+	}
+
+	if p.State != playtest.Cancelled || !p.CancelTime.Valid || p.CancellationReason != "The designer is unwell" {
+		t.Errorf("Cancellation not recorded: %+v", p)
+	}
+
+	data := PlaytestCancelled(p).Data
+	players := data["players"].([]map[string]string)
+	if data["reason"] != "The designer is unwell" || len(players) != 1 || players[0]["email"] != "player@example.com" {
+		t.Errorf("Cancellation event incorrect: %+v", data)
+	}
+
+	if err := p.Cancel("Again"); err == nil {
+		t.Error("Cancelled playtests can't be cancelled again")
+	}
+}
+
+func TestReschedulePlaytest(t *testing.T) {
+	e := &Event{
+		ID:         1,
+		Duration:   4 * time.Hour,
+		SlotLength: 2 * time.Hour,
+		RRule:      "DTSTART:20201202T180000Z\nRRULE:FREQ=WEEKLY;BYDAY=WE",
+	}
+	original := time.Date(2030, 1, 2, 18, 0, 0, 0, time.UTC)
+
+	p := &Playtest{
+		ID:            1,
+		State:         playtest.Seated,
+		Event:         e,
+		EventID:       &e.ID,
+		ScheduledDate: original,
+		Slot:          1,
+		Location:      &playtest.Location{Table: "1"},
+	}
+
+	if err := p.Reschedule(time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC), 1, "Moved"); err == nil {
+		t.Error("Playtests at an event can only move to days the event occurs")
+	}
+
+	if err := p.Reschedule(time.Date(2020, 12, 23, 0, 0, 0, 0, time.UTC), 1, "Moved"); !errors.As(err, &playtest.DateInPast{}) {
+		t.Errorf("Playtests can't move to a day that has passed, got %v", err)
+	}
+
+	if err := p.Reschedule(time.Date(2030, 1, 9, 0, 0, 0, 0, time.UTC), 2, "Moved for a tournament"); err != nil {
+		t.Fatalf("Unexpected error rescheduling playtest: %s", err)
+	}
+
+	if !p.ScheduledDate.Equal(time.Date(2030, 1, 9, 20, 0, 0, 0, time.UTC)) || p.Slot != 2 {
+		t.Errorf("Playtest should move to its new slot, got slot %d at %s", p.Slot, p.ScheduledDate)
+	}
+
+	if !p.RescheduledFrom.Valid || !p.RescheduledFrom.Time.Equal(original) || p.RescheduleReason != "Moved for a tournament" {
+		t.Error("The original date and reason should be kept")
+	}
+
+	if p.State != playtest.Registered || p.Location.Table != "" {
+		t.Error("Rescheduling should give up the table")
+	}
+
+	data := PlaytestRescheduled(p, original).Data
+	if !data["from"].(time.Time).Equal(original) || !data["date"].(time.Time).Equal(p.ScheduledDate) {
+		t.Errorf("Reschedule event incorrect: %+v", data)
+	}
+
+	// Moving again still remembers the very first date
+	p.Reschedule(time.Date(2030, 1, 16, 0, 0, 0, 0, time.UTC), 1, "Moved again")
+	if !p.RescheduledFrom.Time.Equal(original) {
+		t.Error("The original date should survive further moves")
+	}
+
+	p.State = playtest.InProgress
+	if err := p.Reschedule(time.Date(2030, 1, 23, 0, 0, 0, 0, time.UTC), 1, "Too late"); err == nil {
+		t.Error("Playtests that have started can't be rescheduled")
+	}
+}
+
+func TestPlaytestUpdated(t *testing.T) {
+	eventID := uint(3)
+	date := time.Date(2020, 12, 23, 18, 0, 0, 0, time.UTC)
+	previous := time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)
+
+	event := PlaytestUpdated(&Playtest{ID: 1, EventID: &eventID, ScheduledDate: date}, previous)
+	dates := event.Data["dates"].([]time.Time)
+	if event.Data["eventID"].(uint) != 3 || len(dates) != 2 || !dates[0].Equal(date) || !dates[1].Equal(previous) {
+		t.Errorf("Update event incorrect: %+v", event.Data)
+	}
+
+	event = PlaytestUpdated(&Playtest{ID: 2, ScheduledDate: date})
+	if event.Data["eventID"].(uint) != 0 || len(event.Data["dates"].([]time.Time)) != 1 {
+		t.Errorf("Playtests without an event should be broadcast with event 0, got %+v", event.Data)
+	}
+}
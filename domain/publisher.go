@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+	"gorm.io/gorm"
+)
+
+// Publisher is an organization that signs games. Its members review the games designers submit, guided by the
+// criteria the publisher shares about what it's looking for.
+type Publisher struct {
+	ID        uint           `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time      `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+	UpdatedAt time.Time      `json:"updated_at" example:"2020-12-13T15:42:40.578904-08:00"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Name     string             `json:"name" gorm:"uniqueIndex;not null" example:"Coin Flip Games"`
+	Website  string             `json:"website,omitempty" example:"https://coinflipgames.co"`
+	Members  []User             `json:"members" gorm:"many2many:publisher_members;"`
+	OwnerID  uint               `json:"owner_id" gorm:"not null;default:0;index" example:"123"` // Member in charge of who else is a member
+	Criteria publisher.Criteria `json:"criteria" gorm:"embedded;embeddedPrefix:criteria_"`
+}
+
+// PublisherRepository defines how to interact with publishers in database
+type PublisherRepository interface {
+	ListPublishers(accepting bool) ([]Publisher, error)
+	PublisherOfID(id uint) (*Publisher, error)
+	Save(*Publisher) error
+	SaveMembers(*Publisher) error
+}
+
+// NewPublisher creates a publisher that isn't accepting submissions yet, with its owner as the only member
+func NewPublisher(name, website string, owner User) *Publisher {
+	return &Publisher{
+		Name:    name,
+		Website: website,
+		Members: []User{owner},
+		OwnerID: owner.ID,
+	}
+}
+
+// MayBeUpdatedBy checks if the given user has permission to update the publisher and review its submissions.
+// Currently, only members may do so.
+func (p *Publisher) MayBeUpdatedBy(user *User) bool {
+	if user == nil {
+		return false
+	}
+
+	for _, member := range p.Members {
+		if member.ID == user.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rename will change the name of the publisher. Blank names are not allowed.
+func (p *Publisher) Rename(newName string) {
+	if newName != "" && p.Name != newName {
+		p.Name = newName
+	}
+}
+
+// UpdateWebsite replaces the existing website
+func (p *Publisher) UpdateWebsite(newWebsite string) {
+	p.Website = newWebsite
+}
+
+// AddMember will include the provided user as a member of this publisher. Only the owner may add members.
+func (p *Publisher) AddMember(member *User, by *User) error {
+	if by == nil || by.ID != p.OwnerID {
+		return Forbidden{Action: "add members to this publisher"}
+	}
+
+	for _, m := range p.Members {
+		if m.ID == member.ID {
+			return publisher.AlreadyMember{Name: member.Name}
+		}
+	}
+
+	p.Members = append(p.Members, *member)
+
+	return nil
+}
+
+// RemoveMember takes a member off the publisher. Members may leave on their own, while only the owner may
+// remove others. The owner can't be removed, so a publisher always keeps at least one member.
+func (p *Publisher) RemoveMember(memberID uint, by *User) error {
+	index := -1
+	for i, m := range p.Members {
+		if m.ID == memberID {
+			index = i
+		}
+	}
+
+	if index < 0 {
+		return publisher.NotAMember{ProvidedID: memberID}
+	}
+
+	if memberID != by.ID && by.ID != p.OwnerID {
+		return Forbidden{Action: "remove members from this publisher"}
+	}
+
+	if len(p.Members) == 1 {
+		return publisher.LastMember{}
+	}
+
+	if memberID == p.OwnerID {
+		return publisher.OwnerRemoval{}
+	}
+
+	p.Members = append(p.Members[:index], p.Members[index+1:]...)
+
+	return nil
+}
+
+// UpdateCriteria replaces what the publisher is looking for. Calling code is responsible for resolving the
+// mechanics against the taxonomy.
+func (p *Publisher) UpdateCriteria(criteria publisher.Criteria) {
+	p.Criteria = criteria
+}
+
+// recipients lists the name and email of every member, for notifying them about submissions
+func (p *Publisher) recipients() []map[string]string {
+	recipients := []map[string]string{}
+	for _, member := range p.Members {
+		recipients = append(recipients, map[string]string{
+			"name":  member.Name,
+			"email": member.Account.Email,
+		})
+	}
+
+	return recipients
+}
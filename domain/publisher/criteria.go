@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/lib/pq"
+)
+
+// Criteria describe the games a publisher is looking for. Zero values mean the publisher doesn't mind.
+type Criteria struct {
+	AcceptingSubmissions bool           `json:"accepting_submissions" gorm:"not null;default:false" example:"true"`
+	Mechanics            pq.StringArray `json:"mechanics" gorm:"type:text[]" example:"['Deck Building', 'Worker Placement']"`
+	MinPlayers           int            `json:"min_players" example:"2"` // Games must play with as few as this many players
+	MaxPlayers           int            `json:"max_players" example:"6"` // Games must play with as many as this many players
+	MaxPlaytime          int            `json:"max_playtime" example:"60"`
+	Notes                string         `json:"notes" example:"Family weight games with a strong theme"`
+}
+
+// Fits checks if a game with the given stats and mechanics is the kind of game the publisher is looking for.
+// Games only need to share one mechanic with the criteria.
+func (c Criteria) Fits(stats game.Stats, mechanics []string) bool {
+	if c.MinPlayers > 0 && stats.MinPlayers > c.MinPlayers {
+		return false
+	}
+
+	if c.MaxPlayers > 0 && stats.MaxPlayers < c.MaxPlayers {
+		return false
+	}
+
+	if c.MaxPlaytime > 0 && stats.EstimatedPlaytime > c.MaxPlaytime {
+		return false
+	}
+
+	if len(c.Mechanics) == 0 {
+		return true
+	}
+
+	for _, wanted := range c.Mechanics {
+		for _, m := range mechanics {
+			if game.Slugify(wanted) == game.Slugify(m) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
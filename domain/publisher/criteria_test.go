@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+func TestCriteriaFits(t *testing.T) {
+	stats := game.Stats{MinPlayers: 2, MaxPlayers: 5, EstimatedPlaytime: 45}
+	mechanics := []string{"Deck Building", "Hidden Movement"}
+
+	var tests = []struct {
+		criteria Criteria
+		expected bool
+	}{
+		{Criteria{}, true},
+		{Criteria{MinPlayers: 2, MaxPlayers: 4}, true},
+		{Criteria{MinPlayers: 1}, false},
+		{Criteria{MaxPlayers: 6}, false},
+		{Criteria{MaxPlaytime: 30}, false},
+		{Criteria{Mechanics: []string{"deck-building", "Worker Placement"}}, true},
+		{Criteria{Mechanics: []string{"Worker Placement"}}, false},
+	}
+
+	for _, tt := range tests {
+		actual := tt.criteria.Fits(stats, mechanics)
+		if actual != tt.expected {
+			t.Errorf("Criteria %+v expected %t, got %t", tt.criteria, tt.expected, actual)
+		}
+	}
+}
+
+func TestStageTransitions(t *testing.T) {
+	if !Submitted.CanTransitionTo(Reviewing) || !Reviewing.CanTransitionTo(Decided) {
+		t.Error("Expected submissions to move forward through review")
+	}
+
+	if Decided.CanTransitionTo(Reviewing) || Withdrawn.CanTransitionTo(Submitted) {
+		t.Error("Expected closed submissions to stay closed")
+	}
+
+	if Decided.Open() || Withdrawn.Open() || !PrototypeRequested.Open() {
+		t.Error("Open stages incorrect")
+	}
+}
@@ -0,0 +1,88 @@
+package publisher
+
+import "fmt"
+
+// InvalidTransition returned when a submission is asked to skip or revisit a stage of review
+type InvalidTransition struct {
+	From Stage
+	To   Stage
+}
+
+func (e InvalidTransition) Error() string {
+	return fmt.Sprintf("submission cannot move from '%s' to '%s'", e.From, e.To)
+}
+
+// DecisionRequired returned when a submission is decided without accepting or declining it
+type DecisionRequired struct{}
+
+func (e DecisionRequired) Error() string {
+	return "deciding a submission requires accepting or declining it"
+}
+
+// NotAcceptingSubmissions returned when submitting to a publisher that isn't looking for games
+type NotAcceptingSubmissions struct {
+	Publisher string
+}
+
+func (e NotAcceptingSubmissions) Error() string {
+	return fmt.Sprintf("%s is not accepting submissions", e.Publisher)
+}
+
+// AlreadySubmitted returned when a game is submitted to a publisher who hasn't decided on it yet
+type AlreadySubmitted struct {
+	Publisher string
+}
+
+func (e AlreadySubmitted) Error() string {
+	return fmt.Sprintf("this game is already being reviewed by %s", e.Publisher)
+}
+
+// NotAccepted returned when signing a game with a publisher who never accepted it
+type NotAccepted struct {
+	Publisher string
+}
+
+func (e NotAccepted) Error() string {
+	return fmt.Sprintf("%s hasn't accepted this game", e.Publisher)
+}
+
+// InvalidSellSheet returned when the file submitted isn't one of the game's sell sheets
+type InvalidSellSheet struct {
+	ProvidedID uint
+}
+
+func (e InvalidSellSheet) Error() string {
+	return fmt.Sprintf("file '%d' is not a sell sheet for this game", e.ProvidedID)
+}
+
+// LastMember returned when removing a publisher's only member
+type LastMember struct{}
+
+func (e LastMember) Error() string {
+	return "a publisher must have at least one member"
+}
+
+// OwnerRemoval returned when removing a publisher's owner
+type OwnerRemoval struct{}
+
+func (e OwnerRemoval) Error() string {
+	return "the owner of a publisher cannot be removed"
+}
+
+// NotAMember returned when the user is expected to be a member of the publisher, but isn't
+type NotAMember struct {
+	ProvidedID uint
+}
+
+func (e NotAMember) Error() string {
+	return fmt.Sprintf("user '%d' is not a member of this publisher", e.ProvidedID)
+}
+
+// AlreadyMember returned when adding someone who is already a member of the publisher
+type AlreadyMember struct {
+	Name string
+}
+
+func (e AlreadyMember) Error() string {
+	return fmt.Sprintf("%s is already a member of this publisher", e.Name)
+}
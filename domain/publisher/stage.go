@@ -0,0 +1,60 @@
+package publisher
+
+// Stage tracks where a submission is in a publisher's review
+type Stage string
+
+const (
+	// Submitted games are waiting for the publisher to look at them
+	Submitted Stage = "Submitted"
+
+	// Reviewing games are being looked at by the publisher
+	Reviewing Stage = "Reviewing"
+
+	// PrototypeRequested games caught the publisher's eye, and they'd like to play a copy
+	PrototypeRequested Stage = "PrototypeRequested"
+
+	// Decided submissions have been accepted or declined
+	Decided Stage = "Decided"
+
+	// Withdrawn submissions were pulled by the designers before a decision was made
+	Withdrawn Stage = "Withdrawn"
+)
+
+// transitions lists every stage a submission may move to from its current stage
+var transitions = map[Stage][]Stage{
+	Submitted:          {Reviewing, Decided, Withdrawn},
+	Reviewing:          {PrototypeRequested, Decided, Withdrawn},
+	PrototypeRequested: {Reviewing, Decided, Withdrawn},
+	Decided:            {},
+	Withdrawn:          {},
+}
+
+// CanTransitionTo checks if moving from this stage to the next one is allowed
+func (s Stage) CanTransitionTo(next Stage) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Open checks if the submission is still waiting on a decision
+func (s Stage) Open() bool {
+	return s != Decided && s != Withdrawn
+}
+
+// Decision is the publisher's answer to a submission
+type Decision string
+
+const (
+	// Undecided submissions are still under review
+	Undecided Decision = ""
+
+	// Accepted submissions are ones the publisher wants to sign
+	Accepted Decision = "Accepted"
+
+	// Declined submissions aren't a fit for the publisher
+	Declined Decision = "Declined"
+)
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+// Rulebook is everything needed to print a game's rules as a single document: a title page, a table of
+// contents and every section in order
+type Rulebook struct {
+	Title     string
+	Overview  string
+	Stats     game.Stats
+	Designers []string
+	Sections  []game.RulesSection
+}
+
+// Rulebook assembles the game's rules sections, in order, into a printable document
+func (g *Game) Rulebook(rules []game.RulesSection) Rulebook {
+	sections := append([]game.RulesSection{}, rules...)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].OrderBy < sections[j].OrderBy
+	})
+
+	designers := []string{}
+	for _, d := range g.Designers {
+		designers = append(designers, d.Name)
+	}
+
+	return Rulebook{
+		Title:     g.Title,
+		Overview:  g.Overview,
+		Stats:     g.Stats,
+		Designers: designers,
+		Sections:  sections,
+	}
+}
+
+// Contents lists the title of every section, in order, for the table of contents
+func (b Rulebook) Contents() []string {
+	contents := []string{}
+	for _, section := range b.Sections {
+		contents = append(contents, section.Title)
+	}
+
+	return contents
+}
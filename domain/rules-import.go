@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+// RulesImport is what happens to a game's rules when a document is imported into them. Sections are listed
+// in their new order, followed by any that were removed.
+type RulesImport struct {
+	Replace  bool              `json:"replace" example:"false"`
+	Sections []ImportedSection `json:"sections"`
+	Rejected []game.Rejection  `json:"rejected"`
+
+	// Changes still to be saved, each with the revision that records it
+	Changes []RulesChange `json:"-"`
+}
+
+// ImportedSection is a single section of the rules after an import, and whether the import touched it
+type ImportedSection struct {
+	Status  game.SectionStatus `json:"status" example:"Changed"`
+	Section *game.RulesSection `json:"section"`
+}
+
+// RulesChange pairs a rules section that was added, changed or removed with the revision recording it
+type RulesChange struct {
+	Section  *game.RulesSection
+	Revision *RulesRevision
+}
+
+// ImportRules brings sections from an imported document into the game's rules. When merging, sections
+// with the same title (ignoring case) have their content replaced and the rest are added after whichever
+// matched section they follow in the document. When replacing, every existing section is removed first.
+// The game's rules must be loaded.
+func (g *Game) ImportRules(imported []game.RulesSection, replace bool, author *User) *RulesImport {
+	result := &RulesImport{Replace: replace, Rejected: []game.Rejection{}}
+
+	sort.SliceStable(g.Rules, func(i, j int) bool {
+		return g.Rules[i].OrderBy < g.Rules[j].OrderBy
+	})
+
+	removed := []game.RulesSection{}
+	if replace {
+		removed = append(removed, g.Rules...)
+		g.Rules = []game.RulesSection{}
+	}
+
+	changed := map[uint]bool{}
+	matched := map[uint]bool{}
+	following := map[uint][]game.RulesSection{}
+	previous := uint(0)
+	for _, section := range imported {
+		existing := g.matchRulesSection(section.Title, matched)
+		if existing == nil {
+			added, rejected := g.AddRulesSection(section.Title, section.Content)
+			result.Rejected = append(result.Rejected, rejected...)
+			following[previous] = append(following[previous], *added)
+			continue
+		}
+
+		matched[existing.ID] = true
+		previous = existing.ID
+		// Content is only comparable once it's been sanitized
+		title, content := existing.Title, existing.Content
+		existing.UpdateTitle(section.Title)
+		result.Rejected = append(result.Rejected, existing.UpdateContent(section.Content)...)
+		changed[existing.ID] = existing.Title != title || existing.Content != content
+	}
+
+	if !replace {
+		g.placeNewRulesSections(following, matched, changed)
+	}
+
+	// The rules are in their new order now. Sections are only pointed to once the rules have stopped growing.
+	for i := range g.Rules {
+		section := &g.Rules[i]
+
+		var status game.SectionStatus
+		switch {
+		case section.ID == 0:
+			status = game.SectionAdded
+		case changed[section.ID]:
+			status = game.SectionChanged
+		default:
+			status = game.SectionUnchanged
+		}
+
+		if status != game.SectionUnchanged {
+			result.Changes = append(result.Changes, RulesChange{
+				Section:  section,
+				Revision: ReviseRulesSection(section, author),
+			})
+		}
+
+		result.Sections = append(result.Sections, ImportedSection{Status: status, Section: section})
+	}
+
+	for i := range removed {
+		result.Changes = append(result.Changes, RulesChange{
+			Section:  &removed[i],
+			Revision: RemoveRulesSectionRevision(&removed[i], author),
+		})
+		result.Sections = append(result.Sections, ImportedSection{Status: game.SectionRemoved, Section: &removed[i]})
+	}
+
+	return result
+}
+
+// placeNewRulesSections moves sections added by a merge from the end of the rules to just after the matched
+// section they follow in the document. Sections ahead of every match go in front of the first one. Existing
+// sections are only renumbered when they have to make room, and are marked as changed when they are.
+func (g *Game) placeNewRulesSections(following map[uint][]game.RulesSection, matched, changed map[uint]bool) {
+	ordered := make([]game.RulesSection, 0, len(g.Rules))
+	leading := true
+	for _, section := range g.Rules {
+		if section.ID == 0 {
+			continue
+		}
+
+		if leading && matched[section.ID] {
+			ordered = append(ordered, following[0]...)
+			leading = false
+		}
+
+		ordered = append(ordered, section)
+		ordered = append(ordered, following[section.ID]...)
+	}
+
+	if leading {
+		ordered = append(ordered, following[0]...)
+	}
+
+	for i := range ordered {
+		section := &ordered[i]
+		if i == 0 && section.ID != 0 {
+			continue
+		}
+
+		next := uint(0)
+		if i > 0 {
+			next = ordered[i-1].OrderBy + 1
+		}
+
+		if section.ID != 0 && section.OrderBy >= next {
+			continue
+		}
+
+		if section.ID != 0 {
+			changed[section.ID] = true
+		}
+		section.UpdateOrder(next)
+	}
+
+	g.Rules = ordered
+}
+
+// matchRulesSection finds the first saved section with the given title that hasn't been matched already
+func (g *Game) matchRulesSection(title string, matched map[uint]bool) *game.RulesSection {
+	for i := range g.Rules {
+		section := &g.Rules[i]
+		if section.ID != 0 && !matched[section.ID] && strings.EqualFold(strings.TrimSpace(section.Title), strings.TrimSpace(title)) {
+			return section
+		}
+	}
+
+	return nil
+}
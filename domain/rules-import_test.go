@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+func TestImportRulesMerge(t *testing.T) {
+	type expectedSection struct {
+		title  string
+		status game.SectionStatus
+	}
+
+	var tests = []struct {
+		name     string
+		game     *Game
+		imported []game.RulesSection
+		expected []expectedSection
+		changes  int
+	}{
+		{
+			"sections are matched by title, changed and added",
+			&Game{ID: 1, Rules: []game.RulesSection{
+				{ID: 11, GameID: 1, Title: "Setup", Content: "<p>Shuffle</p>", OrderBy: 1},
+				{ID: 10, GameID: 1, Title: "Components", Content: "<ul><li>52 Cards</li></ul>", OrderBy: 0},
+			}},
+			[]game.RulesSection{
+				{Title: "components", Content: "<ul><li>54 Cards</li></ul>"},
+				{Title: "Setup", Content: "<p>Shuffle</p>"},
+				{Title: "Scoring", Content: "<p>Most points wins</p>"},
+			},
+			[]expectedSection{{"components", game.SectionChanged}, {"Setup", game.SectionUnchanged}, {"Scoring", game.SectionAdded}},
+			2,
+		},
+		{
+			"new sections keep their place in the document",
+			&Game{ID: 1, Rules: []game.RulesSection{
+				{ID: 11, GameID: 1, Title: "Setup", Content: "<p>Shuffle</p>", OrderBy: 1},
+				{ID: 10, GameID: 1, Title: "Components", Content: "<ul><li>52 Cards</li></ul>", OrderBy: 0},
+			}},
+			[]game.RulesSection{
+				{Title: "Overview", Content: "<p>Race snails</p>"},
+				{Title: "Components", Content: "<ul><li>52 Cards</li></ul>"},
+				{Title: "Goal", Content: "<p>Finish first</p>"},
+				{Title: "Setup", Content: "<p>Shuffle</p>"},
+			},
+			[]expectedSection{{"Overview", game.SectionAdded}, {"Components", game.SectionChanged}, {"Goal", game.SectionAdded}, {"Setup", game.SectionChanged}},
+			4, // Moved sections are saved along with the new ones
+		},
+	}
+
+	for _, tt := range tests {
+		result := tt.game.ImportRules(tt.imported, false, &User{ID: 5})
+
+		if len(result.Sections) != len(tt.expected) {
+			t.Errorf("%s: expected %d sections, got %+v", tt.name, len(tt.expected), result.Sections)
+			continue
+		}
+
+		for i, e := range tt.expected {
+			section := result.Sections[i]
+			if section.Section.Title != e.title || section.Status != e.status || section.Section.OrderBy != uint(i) {
+				t.Errorf("%s: section %d expected %s (%s), got %s (%s) at %d", tt.name, i, e.title, e.status, section.Section.Title, section.Status, section.Section.OrderBy)
+			}
+		}
+
+		if len(result.Changes) != tt.changes {
+			t.Errorf("%s: expected %d changes, got %d", tt.name, tt.changes, len(result.Changes))
+		}
+
+		for _, change := range result.Changes {
+			if change.Revision.AuthorID != 5 || change.Revision.Removed {
+				t.Errorf("%s: expected changes to be saved as revisions by the importer, got %+v", tt.name, change.Revision)
+			}
+		}
+	}
+}
+
+func TestImportRulesReplace(t *testing.T) {
+	g := &Game{
+		ID: 1,
+		Rules: []game.RulesSection{
+			{ID: 11, GameID: 1, Title: "Setup", Content: "<p>Shuffle</p>", OrderBy: 1},
+			{ID: 10, GameID: 1, Title: "Components", Content: "<ul><li>52 Cards</li></ul>", OrderBy: 0},
+		},
+	}
+
+	result := g.ImportRules([]game.RulesSection{
+		{Title: "Setup", Content: "<p>Deal 5 cards</p>"},
+	}, true, &User{ID: 5})
+
+	if len(g.Rules) != 1 || g.Rules[0].ID != 0 || g.Rules[0].OrderBy != 0 {
+		t.Errorf("Expected rules to be replaced, got %+v", g.Rules)
+	}
+
+	if len(result.Sections) != 3 || result.Sections[0].Status != game.SectionAdded {
+		t.Fatalf("Expected an added section and 2 removed, got %+v", result.Sections)
+	}
+
+	removed := 0
+	for _, change := range result.Changes {
+		if change.Revision.Removed {
+			removed++
+		}
+	}
+
+	if removed != 2 {
+		t.Errorf("Expected 2 sections removed, got %d", removed)
+	}
+}
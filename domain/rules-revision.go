@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+// RulesRevision records a rules section as it stood after a single change, along with who made the change.
+// Together, a game's revisions make up the full history of its rules.
+type RulesRevision struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	GameID    uint `json:"-" gorm:"index"`
+	SectionID uint `json:"section_id" gorm:"index" example:"123"`
+	Author    User `json:"author"`
+	AuthorID  uint `json:"-"`
+
+	Title          string `json:"title" example:"Components"`
+	Content        string `json:"content" example:"<ul><li>52 Cards</li><li>10 dice</li>..."`
+	OrderBy        uint   `json:"order" example:"0"`
+	Removed        bool   `json:"removed" example:"false"`
+	RestoredFromID *uint  `json:"restored_from,omitempty" example:"120"`
+}
+
+// ReviseRulesSection records the section as it stands now. New sections get their ID once saved,
+// so the repository fills in the revision's section then.
+func ReviseRulesSection(section *game.RulesSection, author *User) *RulesRevision {
+	return &RulesRevision{
+		GameID:    section.GameID,
+		SectionID: section.ID,
+		AuthorID:  author.ID,
+		Title:     section.Title,
+		Content:   section.Content,
+		OrderBy:   section.OrderBy,
+	}
+}
+
+// RemoveRulesSectionRevision records that the section was deleted
+func RemoveRulesSectionRevision(section *game.RulesSection, author *User) *RulesRevision {
+	revision := ReviseRulesSection(section, author)
+	revision.Removed = true
+
+	return revision
+}
+
+// RestoreRulesRevision puts a section's title and content back the way they were at an earlier revision.
+// Sections keep their current place in the rules, while those that have since been deleted are brought back
+// at the end. The game's rules must be loaded.
+func (g *Game) RestoreRulesRevision(revision *RulesRevision) (*game.RulesSection, error) {
+	if revision.GameID != g.ID || revision.Removed {
+		return nil, game.RevisionNotFound{ProvidedID: revision.ID}
+	}
+
+	section, err := g.RulesSection(revision.SectionID)
+	if err != nil {
+		section, _ = g.AddRulesSection(revision.Title, revision.Content)
+		section.ID = revision.SectionID
+
+		return section, nil
+	}
+
+	section.UpdateTitle(revision.Title)
+	section.UpdateContent(revision.Content)
+
+	return section, nil
+}
+
+// RulesAtRevision works out what every rules section looked like right after the given revision was made
+func RulesAtRevision(revisions []RulesRevision, revisionID uint) []RulesRevision {
+	return rulesAsOf(revisions, func(r RulesRevision) bool {
+		return r.ID <= revisionID
+	})
+}
+
+// RulesAtTime works out what every rules section looked like at a particular time
+func RulesAtTime(revisions []RulesRevision, at time.Time) []RulesRevision {
+	return rulesAsOf(revisions, func(r RulesRevision) bool {
+		return !r.CreatedAt.After(at)
+	})
+}
+
+// rulesAsOf plays back every revision that passes the filter, keeping the latest for each section.
+// Sections that were deleted are left out, and the rest are sorted the way they'd appear in the rule book.
+func rulesAsOf(revisions []RulesRevision, include func(RulesRevision) bool) []RulesRevision {
+	sorted := append([]RulesRevision{}, revisions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	latest := map[uint]RulesRevision{}
+	for _, r := range sorted {
+		if include(r) {
+			latest[r.SectionID] = r
+		}
+	}
+
+	rules := []RulesRevision{}
+	for _, r := range latest {
+		if !r.Removed {
+			rules = append(rules, r)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].OrderBy == rules[j].OrderBy {
+			return rules[i].SectionID < rules[j].SectionID
+		}
+
+		return rules[i].OrderBy < rules[j].OrderBy
+	})
+
+	return rules
+}
+
+// DiffRules compares two snapshots of a game's rules section by section. Sections are listed in the order
+// of the later snapshot, followed by any that were removed.
+func DiffRules(from, to []RulesRevision) []game.SectionDiff {
+	before := map[uint]RulesRevision{}
+	for _, r := range from {
+		before[r.SectionID] = r
+	}
+
+	after := map[uint]bool{}
+	diffs := []game.SectionDiff{}
+	for _, r := range to {
+		after[r.SectionID] = true
+
+		old, existed := before[r.SectionID]
+		diff := game.SectionDiff{
+			SectionID: r.SectionID,
+			Status:    game.SectionUnchanged,
+			FromTitle: old.Title,
+			ToTitle:   r.Title,
+			Lines:     game.DiffLines(old.Content, r.Content),
+		}
+
+		switch {
+		case !existed:
+			diff.Status = game.SectionAdded
+		case old.Title != r.Title || old.OrderBy != r.OrderBy || changedLines(diff.Lines):
+			diff.Status = game.SectionChanged
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	for _, r := range from {
+		if after[r.SectionID] {
+			continue
+		}
+
+		diffs = append(diffs, game.SectionDiff{
+			SectionID: r.SectionID,
+			Status:    game.SectionRemoved,
+			FromTitle: r.Title,
+			Lines:     game.DiffLines(r.Content, ""),
+		})
+	}
+
+	return diffs
+}
+
+// changedLines checks if a diff has any additions or removals
+func changedLines(lines []game.LineDiff) bool {
+	for _, line := range lines {
+		if line.Op != game.Unchanged {
+			return true
+		}
+	}
+
+	return false
+}
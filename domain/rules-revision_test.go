@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+func TestRulesAsOf(t *testing.T) {
+	march := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	april := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	revisions := []RulesRevision{
+		{ID: 1, CreatedAt: march, SectionID: 1, Title: "Components", Content: "<li>52 Cards</li><li>10 dice</li>", OrderBy: 0},
+		{ID: 2, CreatedAt: march, SectionID: 2, Title: "Setup", Content: "<p>Shuffle</p>", OrderBy: 1},
+		{ID: 3, CreatedAt: march, SectionID: 3, Title: "Variants", Content: "<p>Solo</p>", OrderBy: 2},
+		{ID: 4, CreatedAt: april, SectionID: 1, Title: "Components", Content: "<li>52 Cards</li><li>12 dice</li>", OrderBy: 0},
+		{ID: 5, CreatedAt: april, SectionID: 3, Title: "Variants", Content: "<p>Solo</p>", OrderBy: 2, Removed: true},
+		{ID: 6, CreatedAt: april, SectionID: 4, Title: "Scoring", Content: "<p>Most points wins</p>", OrderBy: 3},
+	}
+
+	var tests = []struct {
+		name     string
+		rules    []RulesRevision
+		expected []uint // Revision IDs, in order
+	}{
+		{"at revision 3", RulesAtRevision(revisions, 3), []uint{1, 2, 3}},
+		{"at revision 6", RulesAtRevision(revisions, 6), []uint{4, 2, 6}},
+		{"at the end of April", RulesAtTime(revisions, time.Date(2021, 4, 30, 0, 0, 0, 0, time.UTC)), []uint{4, 2, 6}},
+		{"before the first revision", RulesAtTime(revisions, time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)), []uint{}},
+	}
+
+	for _, tt := range tests {
+		actual := []uint{}
+		for _, r := range tt.rules {
+			actual = append(actual, r.ID)
+		}
+
+		if !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("Rules %s: expected revisions %v, got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestDiffRules(t *testing.T) {
+	before := []RulesRevision{
+		{ID: 1, SectionID: 1, Title: "Components", Content: "<li>52 Cards</li><li>10 dice</li>", OrderBy: 0},
+		{ID: 2, SectionID: 2, Title: "Setup", Content: "<p>Shuffle</p>", OrderBy: 1},
+		{ID: 3, SectionID: 3, Title: "Variants", Content: "<p>Solo</p>", OrderBy: 2},
+	}
+	after := []RulesRevision{
+		{ID: 4, SectionID: 1, Title: "Components", Content: "<li>52 Cards</li><li>12 dice</li>", OrderBy: 0},
+		{ID: 2, SectionID: 2, Title: "Setup", Content: "<p>Shuffle</p>", OrderBy: 1},
+		{ID: 6, SectionID: 4, Title: "Scoring", Content: "<p>Most points wins</p>", OrderBy: 3},
+	}
+
+	diffs := DiffRules(before, after)
+	if len(diffs) != 4 {
+		t.Fatalf("Expected 4 sections, got %+v", diffs)
+	}
+
+	expected := []struct {
+		section uint
+		status  game.SectionStatus
+	}{
+		{1, game.SectionChanged},
+		{2, game.SectionUnchanged},
+		{4, game.SectionAdded},
+		{3, game.SectionRemoved},
+	}
+
+	for i, e := range expected {
+		if diffs[i].SectionID != e.section || diffs[i].Status != e.status {
+			t.Errorf("Section %d: expected %d to be %s, got %+v", i, e.section, e.status, diffs[i])
+		}
+	}
+
+	if len(diffs[0].Lines) != 3 || diffs[0].Lines[1].Op != game.Removed || diffs[0].Lines[2].Text != "<li>12 dice</li>" {
+		t.Errorf("Changed lines incorrect: %+v", diffs[0].Lines)
+	}
+
+	if len(diffs[3].Lines) != 1 || diffs[3].Lines[0].Op != game.Removed {
+		t.Errorf("Removed sections should have every line removed, got %+v", diffs[3].Lines)
+	}
+}
+
+func TestRestoreRulesRevision(t *testing.T) {
+	g := &Game{ID: 1, Rules: []game.RulesSection{{ID: 1, GameID: 1, Title: "Components", Content: "<li>12 dice</li>", OrderBy: 0}}}
+
+	section, err := g.RestoreRulesRevision(&RulesRevision{ID: 1, GameID: 1, SectionID: 1, Title: "Parts", Content: "<li>10 dice</li>", OrderBy: 4})
+	if err != nil || section.ID != 1 || section.Title != "Parts" || section.Content != "<li>10 dice</li>" || section.OrderBy != 0 {
+		t.Errorf("Existing section should be restored in place, got %+v", section)
+	}
+
+	section, err = g.RestoreRulesRevision(&RulesRevision{ID: 3, GameID: 1, SectionID: 3, Title: "Variants", Content: "<p>Solo</p>"})
+	if err != nil || section.ID != 3 || section.OrderBy != 1 || len(g.Rules) != 2 {
+		t.Errorf("Deleted section should be brought back at the end, got %+v", section)
+	}
+
+	if _, err := g.RestoreRulesRevision(&RulesRevision{ID: 5, GameID: 2, SectionID: 3}); err == nil {
+		t.Error("Revisions of other games can't be restored")
+	}
+
+	if _, err := g.RestoreRulesRevision(&RulesRevision{ID: 5, GameID: 1, SectionID: 3, Removed: true}); err == nil {
+		t.Error("Deletions can't be restored")
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+// SeatResult is how a single player fared in a playtest
+type SeatResult struct {
+	ID        uint      `json:"-" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	PlaytestID uint `json:"-" gorm:"index"`
+	User       User `json:"user"`
+	UserID     uint `json:"-"`
+	Seat       uint `json:"seat" example:"1"` // Turn order, starting from 1
+	Score      *int `json:"score,omitempty" example:"42"`
+	Winner     bool `json:"winner" example:"true"`
+}
+
+// RecordOutcome stores how the game ended and how each player fared. It may be recorded once play is over,
+// and recording it again replaces the previous results.
+func (p *Playtest) RecordOutcome(ending playtest.Ending, results []SeatResult) error {
+	if p.State != playtest.Feedback && p.State != playtest.Finished {
+		return playtest.InvalidOutcome{Reason: "results can only be recorded once play is over"}
+	}
+
+	players := map[uint]bool{}
+	seats := map[uint]bool{}
+	for i, r := range results {
+		if !p.HasPlayer(&User{ID: r.UserID}) {
+			return playtest.InvalidOutcome{Reason: fmt.Sprintf("user %d did not play in this playtest", r.UserID)}
+		}
+
+		if players[r.UserID] {
+			return playtest.InvalidOutcome{Reason: fmt.Sprintf("user %d has more than one result", r.UserID)}
+		}
+
+		if r.Seat == 0 || seats[r.Seat] {
+			return playtest.InvalidOutcome{Reason: fmt.Sprintf("seat %d is invalid or taken", r.Seat)}
+		}
+
+		players[r.UserID] = true
+		seats[r.Seat] = true
+		results[i].PlaytestID = p.ID
+
+		for _, player := range p.Players {
+			if player.ID == r.UserID {
+				results[i].User = player
+			}
+		}
+	}
+
+	p.Ending = ending
+	p.Results = results
+
+	return nil
+}
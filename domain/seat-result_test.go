@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+func TestRecordOutcome(t *testing.T) {
+	score := 42
+	players := []User{User{ID: 1, Name: "One"}, User{ID: 2, Name: "Two"}}
+
+	var tests = []struct {
+		state       playtest.State
+		results     []SeatResult
+		expectError bool
+	}{
+		{playtest.Feedback, []SeatResult{{UserID: 1, Seat: 1, Score: &score, Winner: true}, {UserID: 2, Seat: 2}}, false},
+		{playtest.Finished, []SeatResult{}, false},
+		{playtest.InProgress, []SeatResult{{UserID: 1, Seat: 1}}, true},
+		{playtest.Feedback, []SeatResult{{UserID: 3, Seat: 1}}, true},
+		{playtest.Feedback, []SeatResult{{UserID: 1, Seat: 1}, {UserID: 1, Seat: 2}}, true},
+		{playtest.Feedback, []SeatResult{{UserID: 1, Seat: 1}, {UserID: 2, Seat: 1}}, true},
+		{playtest.Feedback, []SeatResult{{UserID: 1, Seat: 0}}, true},
+	}
+
+	for _, tt := range tests {
+		p := &Playtest{ID: 7, State: tt.state, Players: players}
+		err := p.RecordOutcome(playtest.Completed, tt.results)
+
+		if tt.expectError {
+			if _, ok := err.(playtest.InvalidOutcome); !ok {
+				t.Errorf("Expected InvalidOutcome error, got '%v'", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error recording outcome: %s", err)
+			continue
+		}
+
+		if p.Ending != playtest.Completed || len(p.Results) != len(tt.results) {
+			t.Error("Outcome not recorded on playtest")
+		}
+
+		for _, r := range p.Results {
+			if r.PlaytestID != 7 || r.User.ID != r.UserID {
+				t.Errorf("Result not tied to playtest and player: %+v", r)
+			}
+		}
+	}
+}
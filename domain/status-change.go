@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+// StatusChange records a game moving from one status to another, along with who moved it and why.
+// Together, a game's status changes make up its history from prototype onward.
+type StatusChange struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	GameID      uint        `json:"-" gorm:"index"`
+	From        game.Status `json:"from" example:"Prototype"`
+	To          game.Status `json:"to" example:"Signed"`
+	ChangedBy   User        `json:"changed_by"`
+	ChangedByID uint        `json:"-"`
+	Note        string      `json:"note" example:"Signed at Gen Con!"`
+
+	Publisher    *Publisher `json:"publisher,omitempty"`
+	PublisherID  *uint      `json:"-"`
+	ContractDate *time.Time `json:"contract_date,omitempty" example:"2021-03-01T00:00:00Z"`
+}
+
+// ChangeStatus moves the game along its lifecycle, provided the move is allowed. Signing a game requires the
+// publisher and the date the contract was signed, which are kept until the game goes back to being a prototype.
+// The change is returned for the game's history.
+func (g *Game) ChangeStatus(ns string, by *User, note string, publisher *Publisher, contractDate *time.Time) (*StatusChange, error) {
+	next, err := game.StatusFromString(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.Status.CanTransitionTo(next) {
+		return nil, game.InvalidTransition{From: g.Status, To: next}
+	}
+
+	if next == game.Signed && (publisher == nil || contractDate == nil) {
+		return nil, game.ContractRequired{}
+	}
+
+	change := &StatusChange{
+		GameID:      g.ID,
+		From:        g.Status,
+		To:          next,
+		ChangedBy:   *by,
+		ChangedByID: by.ID,
+		Note:        strings.TrimSpace(note),
+	}
+
+	switch next {
+	case game.Signed:
+		g.Publisher = publisher
+		g.PublisherID = &publisher.ID
+		g.ContractDate = contractDate
+		change.Publisher = publisher
+		change.PublisherID = &publisher.ID
+		change.ContractDate = contractDate
+	case game.Prototype:
+		g.Publisher = nil
+		g.PublisherID = nil
+		g.ContractDate = nil
+	}
+
+	g.Status = next
+
+	return change, nil
+}
+
+// GameStatusChanged lets the game's designers, other than whoever made the change, know its status changed
+func GameStatusChanged(g *Game, change *StatusChange) DomainEvent {
+	publisher := ""
+	if g.Publisher != nil {
+		publisher = g.Publisher.Name
+	}
+
+	return DomainEvent{
+		Name: "Game/StatusChanged",
+		Data: map[string]interface{}{
+			"gameID":    g.ID,
+			"game":      g.Title,
+			"from":      string(change.From),
+			"to":        string(change.To),
+			"note":      change.Note,
+			"publisher": publisher,
+			"changedBy": change.ChangedBy.Name,
+			"designers": g.recipients(change.ChangedByID),
+		},
+	}
+}
+
+// recipients lists the name and email of every designer but the one provided, for notifying them about changes
+// to the game
+func (g *Game) recipients(except uint) []map[string]string {
+	recipients := []map[string]string{}
+	for _, designer := range g.Designers {
+		if designer.ID == except {
+			continue
+		}
+
+		recipients = append(recipients, map[string]string{
+			"name":  designer.Name,
+			"email": designer.Account.Email,
+		})
+	}
+
+	return recipients
+}
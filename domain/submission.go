@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/file"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+)
+
+// Submission is a game pitched to a publisher, along with its sell sheet. Publishers move submissions through
+// their review until they reach a decision, keeping notes along the way that designers never see.
+type Submission struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+	UpdatedAt time.Time `json:"updated_at" example:"2020-12-13T15:42:40.578904-08:00"`
+
+	Game          Game      `json:"game"`
+	GameID        uint      `json:"-" gorm:"index"`
+	Publisher     Publisher `json:"publisher"`
+	PublisherID   uint      `json:"-" gorm:"index"`
+	SubmittedBy   User      `json:"submitted_by"`
+	SubmittedByID uint      `json:"-"`
+	SellSheet     File      `json:"sell_sheet"`
+	SellSheetID   uint      `json:"-"`
+	Pitch         string    `json:"pitch" example:"A quick filler about racing snails"`
+
+	Stage     publisher.Stage    `json:"stage" gorm:"index" example:"Reviewing"`
+	Decision  publisher.Decision `json:"decision,omitempty" example:"Accepted"`
+	DecidedAt *time.Time         `json:"decided_at,omitempty" example:"2021-01-11T15:29:49.321629-08:00"`
+
+	Notes []SubmissionNote `json:"notes,omitempty"` // Private to the publisher
+}
+
+// SubmissionNote is something a publisher's member wants to remember about a submission
+type SubmissionNote struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"123"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	SubmissionID uint   `json:"-" gorm:"index"`
+	Author       User   `json:"author"`
+	AuthorID     uint   `json:"-"`
+	Body         string `json:"body" example:"Loved the theme, scoring felt swingy"`
+}
+
+// SubmissionRepository defines how to interact with submissions in database
+type SubmissionRepository interface {
+	SubmissionsOfPublisher(publisherID uint, stage string, limit, offset int) ([]Submission, int, error)
+	SubmissionsOfGame(gameID uint) ([]Submission, error)
+	SubmissionOfID(id uint) (*Submission, error)
+	Save(*Submission) error
+	SaveReview(*Submission, *StatusChange) error
+	SaveNote(*SubmissionNote) error
+}
+
+// SubmitGame pitches a game to a publisher with one of the game's sell sheets. The publisher must be accepting
+// submissions, and can't already be reviewing the game.
+func SubmitGame(g *Game, p *Publisher, sellSheet *File, pitch string, designer *User, existing []Submission) (*Submission, error) {
+	if !p.Criteria.AcceptingSubmissions {
+		return nil, publisher.NotAcceptingSubmissions{Publisher: p.Name}
+	}
+
+	if sellSheet == nil || sellSheet.GameID == nil || *sellSheet.GameID != g.ID || sellSheet.Role != file.SellSheet {
+		id := uint(0)
+		if sellSheet != nil {
+			id = sellSheet.ID
+		}
+
+		return nil, publisher.InvalidSellSheet{ProvidedID: id}
+	}
+
+	for _, s := range existing {
+		if s.PublisherID == p.ID && s.Stage.Open() {
+			return nil, publisher.AlreadySubmitted{Publisher: p.Name}
+		}
+	}
+
+	s := &Submission{
+		Game:          *g,
+		GameID:        g.ID,
+		Publisher:     *p,
+		PublisherID:   p.ID,
+		SubmittedBy:   *designer,
+		SubmittedByID: designer.ID,
+		SellSheet:     *sellSheet,
+		SellSheetID:   sellSheet.ID,
+		Pitch:         strings.TrimSpace(pitch),
+		Stage:         publisher.Submitted,
+	}
+
+	return s, nil
+}
+
+// MayBeReviewedBy checks if the given user is a member of the publisher the game was submitted to
+func (s *Submission) MayBeReviewedBy(user *User) bool {
+	return s.Publisher.MayBeUpdatedBy(user)
+}
+
+// MayBeViewedBy checks if the given user is on either side of the submission
+func (s *Submission) MayBeViewedBy(user *User) bool {
+	return s.MayBeReviewedBy(user) || s.Game.MayBeUpdatedBy(user)
+}
+
+// Advance moves the submission to the next stage of the publisher's review. Deciding on a submission requires
+// accepting or declining it, while withdrawing is left to the designers. Accepting a game signs it with the
+// publisher, in which case the game's status change is returned for its history.
+func (s *Submission) Advance(next publisher.Stage, decision publisher.Decision, by *User) (*StatusChange, error) {
+	if next == publisher.Withdrawn || !s.Stage.CanTransitionTo(next) {
+		return nil, publisher.InvalidTransition{From: s.Stage, To: next}
+	}
+
+	var signed *StatusChange
+	if next == publisher.Decided {
+		if decision != publisher.Accepted && decision != publisher.Declined {
+			return nil, publisher.DecisionRequired{}
+		}
+
+		now := time.Now()
+		if decision == publisher.Accepted {
+			contractDate := now.Truncate(24 * time.Hour)
+
+			var err error
+			signed, err = s.Game.ChangeStatus(string(game.Signed), by, "Accepted by "+s.Publisher.Name, &s.Publisher, &contractDate)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s.Decision = decision
+		s.DecidedAt = &now
+	}
+
+	s.Stage = next
+
+	return signed, nil
+}
+
+// AcceptedBy checks if the publisher accepted any of the game's submissions. Games are only signed with
+// publishers who accepted them.
+func AcceptedBy(p *Publisher, submissions []Submission) bool {
+	for _, s := range submissions {
+		if s.PublisherID == p.ID && s.Decision == publisher.Accepted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Withdraw pulls the submission from review before the publisher has decided on it
+func (s *Submission) Withdraw() error {
+	if !s.Stage.CanTransitionTo(publisher.Withdrawn) {
+		return publisher.InvalidTransition{From: s.Stage, To: publisher.Withdrawn}
+	}
+
+	s.Stage = publisher.Withdrawn
+
+	return nil
+}
+
+// AddNote records a private note about the submission for the publisher
+func (s *Submission) AddNote(author *User, body string) *SubmissionNote {
+	note := SubmissionNote{
+		SubmissionID: s.ID,
+		Author:       *author,
+		AuthorID:     author.ID,
+		Body:         strings.TrimSpace(body),
+	}
+
+	s.Notes = append(s.Notes, note)
+
+	return &note
+}
+
+// HideNotes strips the publisher's notes, for showing the submission to its designers
+func (s *Submission) HideNotes() {
+	s.Notes = nil
+}
+
+// SubmissionReceived lets every member of the publisher know a game was submitted
+func SubmissionReceived(s *Submission) DomainEvent {
+	return DomainEvent{
+		Name: "Submission/Received",
+		Data: map[string]interface{}{
+			"submissionID": s.ID,
+			"game":         s.Game.Title,
+			"publisher":    s.Publisher.Name,
+			"designer":     s.SubmittedBy.Name,
+			"pitch":        s.Pitch,
+			"recipients":   s.Publisher.recipients(),
+		},
+	}
+}
+
+// SubmissionUpdated lets the other side of the submission know it moved. Withdrawals are passed on to the
+// publisher, while everything else is passed on to the designers.
+func SubmissionUpdated(s *Submission) DomainEvent {
+	recipients := s.Game.recipients(0)
+	if s.Stage == publisher.Withdrawn {
+		recipients = s.Publisher.recipients()
+	}
+
+	return DomainEvent{
+		Name: "Submission/Updated",
+		Data: map[string]interface{}{
+			"submissionID": s.ID,
+			"game":         s.Game.Title,
+			"publisher":    s.Publisher.Name,
+			"stage":        string(s.Stage),
+			"decision":     string(s.Decision),
+			"recipients":   recipients,
+		},
+	}
+}
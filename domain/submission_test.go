@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/file"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+)
+
+func TestSubmitGame(t *testing.T) {
+	gameID := uint(1)
+	g := &Game{ID: gameID, Title: "Snail Race", Status: game.Prototype, Designers: []User{{ID: 5}}}
+	sellSheet := &File{ID: 3, GameID: &gameID, Role: file.SellSheet}
+
+	var tests = []struct {
+		publisher *Publisher
+		sellSheet *File
+		existing  []Submission
+		expectErr bool
+	}{
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: true}}, sellSheet, nil, false},
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: true}}, sellSheet, []Submission{{PublisherID: 2, Stage: publisher.Reviewing}}, true},
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: true}}, sellSheet, []Submission{{PublisherID: 2, Stage: publisher.Decided}}, false},
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: true}}, sellSheet, []Submission{{PublisherID: 6, Stage: publisher.Reviewing}}, false},
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: true}}, &File{ID: 4, GameID: &gameID, Role: file.Image}, nil, true},
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: true}}, nil, nil, true},
+		{&Publisher{ID: 2, Criteria: publisher.Criteria{AcceptingSubmissions: false}}, sellSheet, nil, true},
+	}
+
+	for i, tt := range tests {
+		s, err := SubmitGame(g, tt.publisher, tt.sellSheet, " Fast and silly ", &User{ID: 5}, tt.existing)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("Case %d: expected the submission to be rejected", i)
+			}
+
+			continue
+		}
+
+		if err != nil || s.Stage != publisher.Submitted || s.SellSheetID != 3 || s.Pitch != "Fast and silly" {
+			t.Errorf("Case %d: submission incorrect: %+v (%v)", i, s, err)
+		}
+	}
+}
+
+func TestAdvanceSubmission(t *testing.T) {
+	var tests = []struct {
+		status        game.Status
+		next          publisher.Stage
+		decision      publisher.Decision
+		expectErr     bool
+		expectSigned  bool
+		expectedStage publisher.Stage
+	}{
+		{game.Prototype, publisher.Withdrawn, publisher.Undecided, true, false, publisher.Submitted}, // Only designers withdraw
+		{game.Prototype, publisher.Decided, publisher.Undecided, true, false, publisher.Submitted},   // A decision is required
+		{game.Prototype, publisher.Decided, publisher.Accepted, false, true, publisher.Decided},
+		{game.Prototype, publisher.Decided, publisher.Declined, false, false, publisher.Decided},
+		{game.Published, publisher.Decided, publisher.Accepted, true, false, publisher.Submitted}, // Published games can't be signed
+	}
+
+	for i, tt := range tests {
+		s := &Submission{
+			Game:        Game{ID: 1, Title: "Snail Race", Status: tt.status, Designers: []User{{ID: 5}}},
+			GameID:      1,
+			Publisher:   Publisher{ID: 2, Name: "Coin Flip Games", Members: []User{{ID: 9}}},
+			PublisherID: 2,
+			Stage:       publisher.Submitted,
+		}
+
+		signed, err := s.Advance(tt.next, tt.decision, &User{ID: 9})
+		if (err != nil) != tt.expectErr || s.Stage != tt.expectedStage {
+			t.Errorf("Case %d: expected error %v and stage %s, got %v and %s", i, tt.expectErr, tt.expectedStage, err, s.Stage)
+			continue
+		}
+
+		if tt.expectSigned {
+			if signed == nil || signed.To != game.Signed || s.Game.Status != game.Signed || *s.Game.PublisherID != 2 || s.Game.ContractDate == nil || s.DecidedAt == nil {
+				t.Errorf("Case %d: expected accepting the game to sign it with the publisher, got %+v", i, signed)
+			}
+		} else if signed != nil || s.Game.Status == game.Signed {
+			t.Errorf("Case %d: expected the game to be left alone, got %+v", i, signed)
+		}
+
+		if tt.next == publisher.Decided && !tt.expectErr {
+			if err := s.Withdraw(); err == nil {
+				t.Errorf("Case %d: expected decided submissions to stay decided", i)
+			}
+
+			if recipients := SubmissionUpdated(s).Data["recipients"].([]map[string]string); len(recipients) != 1 {
+				t.Errorf("Case %d: expected the designers to be told about the decision, got %+v", i, recipients)
+			}
+		}
+	}
+}
+
+func TestAcceptedBy(t *testing.T) {
+	p := &Publisher{ID: 2, Name: "Coin Flip Games"}
+
+	var tests = []struct {
+		submissions []Submission
+		expected    bool
+	}{
+		{nil, false},
+		{[]Submission{{PublisherID: 2, Stage: publisher.Reviewing}}, false},
+		{[]Submission{{PublisherID: 2, Stage: publisher.Decided, Decision: publisher.Declined}}, false},
+		{[]Submission{{PublisherID: 3, Stage: publisher.Decided, Decision: publisher.Accepted}}, false},
+		{[]Submission{{PublisherID: 2, Stage: publisher.Withdrawn}, {PublisherID: 2, Stage: publisher.Decided, Decision: publisher.Accepted}}, true},
+	}
+
+	for _, tt := range tests {
+		if actual := AcceptedBy(p, tt.submissions); actual != tt.expected {
+			t.Errorf("Expected %v for submissions %+v", tt.expected, tt.submissions)
+		}
+	}
+}
+
+func TestSubmissionPermissions(t *testing.T) {
+	s := &Submission{
+		Game:      Game{ID: 1, Designers: []User{{ID: 5}}},
+		Publisher: Publisher{ID: 2, Members: []User{{ID: 9}}},
+	}
+	s.AddNote(&User{ID: 9}, "Loved it")
+
+	var tests = []struct {
+		user           *User
+		expectReview   bool
+		expectViewable bool
+	}{
+		{&User{ID: 9}, true, true},
+		{&User{ID: 5}, false, true},
+		{&User{ID: 7}, false, false},
+		{nil, false, false},
+	}
+
+	for _, tt := range tests {
+		if s.MayBeReviewedBy(tt.user) != tt.expectReview || s.MayBeViewedBy(tt.user) != tt.expectViewable {
+			t.Errorf("Permissions incorrect for %+v", tt.user)
+		}
+	}
+
+	s.HideNotes()
+	if len(s.Notes) != 0 {
+		t.Error("Expected notes to be hidden")
+	}
+}
+
+func TestPublisherMembers(t *testing.T) {
+	p := &Publisher{ID: 2, Name: "Coin Flip Games", OwnerID: 9, Members: []User{{ID: 9}}}
+	owner := &User{ID: 9}
+
+	if err := p.AddMember(&User{ID: 4}, &User{ID: 4}); err == nil {
+		t.Error("Expected only the owner to add members")
+	}
+
+	if err := p.AddMember(&User{ID: 4, Name: "Reviewer"}, owner); err != nil || !p.MayBeUpdatedBy(&User{ID: 4}) {
+		t.Errorf("Expected member to be added, got %v", err)
+	}
+
+	if err := p.AddMember(&User{ID: 4, Name: "Reviewer"}, owner); err == nil {
+		t.Error("Expected members to be added only once")
+	}
+
+	if err := p.RemoveMember(9, &User{ID: 4}); err == nil {
+		t.Error("Expected members to be unable to remove others")
+	}
+
+	if err := p.RemoveMember(9, owner); err == nil {
+		t.Error("Expected the owner to be impossible to remove")
+	}
+
+	if err := p.RemoveMember(4, &User{ID: 4}); err != nil || p.MayBeUpdatedBy(&User{ID: 4}) {
+		t.Errorf("Expected members to be able to leave, got %v", err)
+	}
+
+	if err := p.RemoveMember(9, owner); err == nil {
+		t.Error("Expected the last member to be impossible to remove")
+	}
+}
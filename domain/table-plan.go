@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/event"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+// PlanTables proposes a table for every registered playtest in the window from start to end. Tests
+// without a requested duration hold their table for the length of the event. Longer tests are placed first, each at the table where it can start soonest. A test only goes
+// to a table with enough seats for its minimum player count, and tables that also fit the maximum
+// are preferred. Tests that are already seated keep their tables, so new tests are planned around them.
+// Two games sharing a designer are never scheduled at overlapping times.
+func PlanTables(playtests []Playtest, tables []playtest.Table, start, end time.Time, length time.Duration) playtest.Plan {
+	plan := playtest.Plan{
+		Assignments: []playtest.Assignment{},
+		Unassigned:  []playtest.Unassigned{},
+	}
+
+	pending := []Playtest{}
+	for _, p := range playtests {
+		if p.State == playtest.Registered {
+			pending = append(pending, p)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].Requirements.Duration != pending[j].Requirements.Duration {
+			return pending[i].Requirements.Duration > pending[j].Requirements.Duration
+		}
+
+		return pending[i].ID < pending[j].ID
+	})
+
+	// Tables fill up from the start of the window, one test after another
+	nextFree := map[string]time.Time{}
+	for _, t := range tables {
+		nextFree[t.Name] = start
+	}
+
+	// Seated tests already hold their tables
+	taken := []playtest.Assignment{}
+	placed := map[uint]Playtest{}
+	for _, p := range playtests {
+		if p.State != playtest.Seated || p.Location == nil || p.Location.Table == "" {
+			continue
+		}
+
+		taken = append(taken, playtest.Assignment{
+			PlaytestID: p.ID,
+			Table:      p.Location.Table,
+			StartsAt:   p.ScheduledDate,
+			EndsAt:     p.ScheduledDate.Add(p.plannedDuration(length)),
+		})
+		placed[p.ID] = p
+	}
+
+	for _, p := range pending {
+		duration := p.plannedDuration(length)
+
+		var best *playtest.Assignment
+		var bestTable playtest.Table
+		reason := "no table has enough seats"
+
+		for _, t := range tables {
+			if t.Virtual != p.needsVirtualTable() || !t.Fits(p.Requirements.MinPlayers) {
+				continue
+			}
+
+			startsAt := nextFree[t.Name]
+
+			// Tests registered for a slot can't start before it
+			if p.Slot != 0 && p.ScheduledDate.After(startsAt) {
+				startsAt = p.ScheduledDate
+			}
+
+			candidate := playtest.Assignment{
+				PlaytestID: p.ID,
+				Table:      t.Name,
+				StartsAt:   startsAt,
+				EndsAt:     startsAt.Add(duration),
+			}
+
+			// Push the test back until its table is free and none of its designers are busy elsewhere
+			for {
+				conflict := conflictWith(p, candidate, taken, placed)
+				if conflict == nil {
+					break
+				}
+
+				candidate.StartsAt = conflict.EndsAt
+				candidate.EndsAt = conflict.EndsAt.Add(duration)
+			}
+
+			if candidate.EndsAt.After(end) {
+				reason = "no table has room left in the event"
+				continue
+			}
+
+			if best == nil || betterPlacement(candidate, t, *best, bestTable, p.Requirements.MaxPlayers) {
+				c := candidate
+				best = &c
+				bestTable = t
+			}
+		}
+
+		if best == nil {
+			plan.Unassigned = append(plan.Unassigned, playtest.Unassigned{PlaytestID: p.ID, Reason: reason})
+			continue
+		}
+
+		plan.Assignments = append(plan.Assignments, *best)
+		taken = append(taken, *best)
+		placed[p.ID] = p
+		nextFree[best.Table] = best.EndsAt
+	}
+
+	return plan
+}
+
+// betterPlacement prefers whichever assignment starts first. For a tie, tables that fit the maximum
+// number of players win, and after that the table with the fewest spare seats.
+func betterPlacement(a playtest.Assignment, at playtest.Table, b playtest.Assignment, bt playtest.Table, maxPlayers uint) bool {
+	if !a.StartsAt.Equal(b.StartsAt) {
+		return a.StartsAt.Before(b.StartsAt)
+	}
+
+	if at.Fits(maxPlayers) != bt.Fits(maxPlayers) {
+		return at.Fits(maxPlayers)
+	}
+
+	if at.Seats == 0 || bt.Seats == 0 {
+		return bt.Seats == 0 && at.Seats != 0
+	}
+
+	return at.Seats < bt.Seats
+}
+
+// conflictWith finds an existing assignment that overlaps the candidate, either at the same table or for another
+// game by the same designer
+func conflictWith(p Playtest, candidate playtest.Assignment, assignments []playtest.Assignment, placed map[uint]Playtest) *playtest.Assignment {
+	for i, a := range assignments {
+		if !a.Overlaps(candidate) {
+			continue
+		}
+
+		if a.Table == candidate.Table || p.sharesDesignerWith(placed[a.PlaytestID]) {
+			return &assignments[i]
+		}
+	}
+
+	return nil
+}
+
+// sharesDesignerWith checks if the two playtests have any designers in common
+func (p *Playtest) sharesDesignerWith(other Playtest) bool {
+	for _, d := range p.Game.Designers {
+		if other.Game.MayBeUpdatedBy(&d) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// needsVirtualTable checks if the playtest has to happen on Tabletop Simulator
+func (p *Playtest) needsVirtualTable() bool {
+	if p.Location != nil && p.Location.TTSServer != "" {
+		return true
+	}
+
+	return p.Event != nil && p.Event.Type == event.Remote
+}
+
+// plannedDuration is how long to hold a table for. Tests without a requested duration get the whole event.
+func (p *Playtest) plannedDuration(eventLength time.Duration) time.Duration {
+	if p.Requirements.Duration == 0 {
+		return eventLength
+	}
+
+	return time.Duration(p.Requirements.Duration) * time.Minute
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+)
+
+func TestPlanTables(t *testing.T) {
+	start := time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)
+	alice := User{ID: 1}
+	bob := User{ID: 2}
+
+	newPlaytest := func(id uint, designer User, minPlayers, maxPlayers, duration uint) Playtest {
+		return Playtest{
+			ID:    id,
+			State: playtest.Registered,
+			Game:  Game{Designers: []User{designer}},
+			Requirements: playtest.Requirements{
+				MinPlayers: minPlayers,
+				MaxPlayers: maxPlayers,
+				Duration:   duration,
+			},
+		}
+	}
+
+	playtests := []Playtest{
+		newPlaytest(1, alice, 3, 4, 120),
+		newPlaytest(2, alice, 2, 4, 60),
+		newPlaytest(3, bob, 5, 6, 60),
+		newPlaytest(4, bob, 8, 10, 60),
+		{ID: 5, State: playtest.Seated, Game: Game{Designers: []User{bob}}},
+	}
+
+	tables := []playtest.Table{
+		{Name: "Big", Seats: 6},
+		{Name: "Small", Seats: 4},
+	}
+
+	plan := PlanTables(playtests, tables, start, start.Add(4*time.Hour), 4*time.Hour)
+
+	assigned := map[uint]playtest.Assignment{}
+	for _, a := range plan.Assignments {
+		assigned[a.PlaytestID] = a
+	}
+
+	if len(plan.Assignments) != 3 {
+		t.Fatalf("Expected 3 assignments, got %d", len(plan.Assignments))
+	}
+
+	if len(plan.Unassigned) != 1 || plan.Unassigned[0].PlaytestID != 4 {
+		t.Error("Playtest needing more seats than any table should be unassigned")
+	}
+
+	if _, ok := assigned[5]; ok {
+		t.Error("Already seated playtests should be left alone")
+	}
+
+	if assigned[3].Table != "Big" {
+		t.Error("Playtest needing 5 players should be placed at the table with 6 seats")
+	}
+
+	if assigned[1].Table != "Small" || !assigned[1].StartsAt.Equal(start) {
+		t.Error("Longest playtest should be placed first at the smallest table that fits")
+	}
+
+	if assigned[1].Overlaps(assigned[2]) {
+		t.Error("Two games by the same designer should not overlap")
+	}
+}
+
+func TestPlanTablesRespectsWindow(t *testing.T) {
+	start := time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)
+	playtests := []Playtest{
+		{ID: 1, State: playtest.Registered, Requirements: playtest.Requirements{MinPlayers: 2, Duration: 90}},
+		{ID: 2, State: playtest.Registered, Requirements: playtest.Requirements{MinPlayers: 2, Duration: 90}},
+	}
+
+	plan := PlanTables(playtests, []playtest.Table{{Name: "Only"}}, start, start.Add(2*time.Hour), 2*time.Hour)
+
+	if len(plan.Assignments) != 1 || len(plan.Unassigned) != 1 {
+		t.Error("Playtests running past the end of the event should not be assigned")
+	}
+}
+
+func TestPlanTablesAroundSeatedPlaytests(t *testing.T) {
+	start := time.Date(2020, 12, 16, 18, 0, 0, 0, time.UTC)
+	alice := User{ID: 1}
+	playtests := []Playtest{
+		{
+			ID:            1,
+			State:         playtest.Seated,
+			Game:          Game{Designers: []User{alice}},
+			ScheduledDate: start,
+			Location:      &playtest.Location{Table: "Only"},
+			Requirements:  playtest.Requirements{MinPlayers: 2, Duration: 60},
+		},
+		{ID: 2, State: playtest.Registered, Requirements: playtest.Requirements{MinPlayers: 2, Duration: 60}},
+	}
+
+	plan := PlanTables(playtests, []playtest.Table{{Name: "Only"}}, start, start.Add(3*time.Hour), 3*time.Hour)
+
+	if len(plan.Assignments) != 1 || !plan.Assignments[0].StartsAt.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected the registered playtest to wait for the seated one, got %+v", plan.Assignments)
+	}
+}
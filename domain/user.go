@@ -17,9 +17,12 @@ type User struct {
 
 	Name     string       `json:"name" example:"User McUserton"`
 	Account  user.Account `json:"-" gorm:"embedded"`
-	Email    string       `json:"email,omitempty" gorm:"-"` // Only for decorating the json response
+	Email    string       `json:"email,omitempty" gorm:"-"`   // Only for decorating the json response
+	Credits  *int         `json:"credits,omitempty" gorm:"-"` // Only for decorating the json response
 	Pronouns string       `json:"pronouns" example:"they/them"`
 	Color    string       `json:"color" example:"#2a9d8f"`
+	Admin    bool         `json:"-" gorm:"not null;default:false"` // Admins curate shared data, like the mechanics taxonomy
+	IsAdmin  bool         `json:"admin,omitempty" gorm:"-"`        // Only for decorating the json response
 }
 
 // UserRepository defines how to interact with the user in database
@@ -162,6 +165,11 @@ func (u *User) SetColor(newColor string) {
 	u.Color = newColor
 }
 
+// SetAdmin grants or revokes the user's ability to curate shared data
+func (u *User) SetAdmin(admin bool) {
+	u.Admin = admin
+}
+
 // AfterCreate hook for sending welcome emails
 func (u *User) AfterCreate(tx *gorm.DB) error {
 	if !u.Account.Verified {
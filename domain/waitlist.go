@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// WaitlistEntry holds a user's place in line for a playtest that is already full
+type WaitlistEntry struct {
+	ID        uint      `json:"-" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at" example:"2020-12-11T15:29:49.321629-08:00"`
+
+	PlaytestID uint `json:"-" gorm:"index"`
+	User       User `json:"user"`
+	UserID     uint `json:"-"`
+	Position   uint `json:"position" example:"1"`
+}
+
+// PlayerPromoted lets a waitlisted user know they have a seat
+func PlayerPromoted(p *Playtest, u *User) DomainEvent {
+	return DomainEvent{
+		Name: "Playtest/PlayerPromoted",
+		Data: map[string]interface{}{
+			"playtestID": p.ID,
+			"game":       p.Game.Title,
+			"date":       p.ScheduledDate,
+			"name":       u.Name,
+			"email":      u.Account.Email,
+		},
+	}
+}
+
+// IsFull checks if the playtest has reached its maximum number of players. Playtests without a maximum are never full.
+func (p *Playtest) IsFull() bool {
+	return p.Requirements.MaxPlayers > 0 && uint(len(p.Players)) >= p.Requirements.MaxPlayers
+}
+
+// IsWaitlisted checks if the given user is waiting for a seat in this test
+func (p *Playtest) IsWaitlisted(user *User) bool {
+	if user == nil {
+		return false
+	}
+
+	for _, entry := range p.Waitlist {
+		if entry.UserID == user.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinWaitlist puts the user at the back of the line
+func (p *Playtest) joinWaitlist(user *User) {
+	if p.IsWaitlisted(user) {
+		return
+	}
+
+	p.Waitlist = append(p.Waitlist, WaitlistEntry{
+		PlaytestID: p.ID,
+		User:       *user,
+		UserID:     user.ID,
+		Position:   uint(len(p.Waitlist) + 1),
+	})
+}
+
+// leaveWaitlist takes the user out of line and moves everyone behind them up
+func (p *Playtest) leaveWaitlist(user *User) {
+	for i, entry := range p.Waitlist {
+		if entry.UserID == user.ID {
+			p.Waitlist = append(p.Waitlist[:i], p.Waitlist[i+1:]...)
+			p.renumberWaitlist()
+
+			return
+		}
+	}
+}
+
+// promoteFromWaitlist seats the next user in line, provided there's room and the test hasn't started
+func (p *Playtest) promoteFromWaitlist() *User {
+	if len(p.Waitlist) == 0 || p.IsFull() || !p.acceptingPlayers() {
+		return nil
+	}
+
+	next := p.Waitlist[0].User
+	p.Waitlist = p.Waitlist[1:]
+	p.renumberWaitlist()
+	p.Players = append(p.Players, next)
+
+	return &next
+}
+
+func (p *Playtest) renumberWaitlist() {
+	for i := range p.Waitlist {
+		p.Waitlist[i].Position = uint(i + 1)
+	}
+}
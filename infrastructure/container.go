@@ -11,6 +11,7 @@ import (
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/persistence"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/secret"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/validation"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/ui/controller"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/ui/events"
@@ -30,20 +31,31 @@ import (
 // Container is a lazy-load dependency injection container
 type Container struct {
 	// Application
-	authService     *app.AuthService
-	eventService    *app.EventService
-	fileService     *app.FileService
-	gameService     *app.GameService
-	mailService     *app.MailService
-	playtestService *app.PlaytestService
-	userService     *app.UserService
+	analyticsService *app.AnalyticsService
+	authService      *app.AuthService
+	designerService  *app.DesignerService
+	eventService     *app.EventService
+	feedbackService  *app.FeedbackService
+	fileService      *app.FileService
+	gameService      *app.GameService
+	mailService      *app.MailService
+	mechanicService  *app.MechanicService
+	playtestService  *app.PlaytestService
+	publisherService *app.PublisherService
+	userService      *app.UserService
 
 	// Domain
+	creditRepository       domain.CreditRepository
+	designerRepository     domain.DesignerRepository
 	eventRepository        domain.EventRepository
+	feedbackRepository     domain.FeedbackRepository
 	fileRepository         domain.FileRepository
 	gameRepository         domain.GameRepository
 	loginAttemptRepository domain.LoginAttemptRepository
+	mechanicRepository     domain.MechanicRepository
 	playtestRepository     domain.PlaytestRepository
+	publisherRepository    domain.PublisherRepository
+	submissionRepository   domain.SubmissionRepository
 	userRepository         domain.UserRepository
 
 	// Infrastructure
@@ -56,16 +68,36 @@ type Container struct {
 	templates map[string]*template.Template
 
 	// UI
-	authController     *controller.AuthController
-	eventController    *controller.EventController
-	fileController     *controller.FileController
-	gameController     *controller.GameController
-	playtestController *controller.PlaytestController
-	userController     *controller.UserController
+	analyticsController *controller.AnalyticsController
+	authController      *controller.AuthController
+	designerController  *controller.DesignerController
+	eventController     *controller.EventController
+	feedbackController  *controller.FeedbackController
+	fileController      *controller.FileController
+	gameController      *controller.GameController
+	mechanicController  *controller.MechanicController
+	playtestController  *controller.PlaytestController
+	publisherController *controller.PublisherController
+	userController      *controller.UserController
 
 	authenticated gin.HandlerFunc
 
 	eventHandler *events.EventHandler
+	board        *events.PlaytestBoard
+}
+
+// AnalyticsService for computing balance analytics from playtest outcomes
+func (c *Container) AnalyticsService() *app.AnalyticsService {
+	if c.analyticsService == nil {
+		c.analyticsService = &app.AnalyticsService{
+			EventRepository:    c.EventRepository(),
+			GameRepository:     c.GameRepository(),
+			PlaytestRepository: c.PlaytestRepository(),
+			Logger:             c.Logger(),
+		}
+	}
+
+	return c.analyticsService
 }
 
 // AuthService for handling authentication & authorization
@@ -73,6 +105,7 @@ func (c *Container) AuthService() *app.AuthService {
 	if c.authService == nil {
 		c.authService = &app.AuthService{
 			AuthToken:              os.Getenv("AUTH_TOKEN"),
+			CreditRepository:       c.CreditRepository(),
 			Logger:                 c.Logger(),
 			LoginAttemptRepository: c.LoginAttemptRepository(),
 			UserRepository:         c.UserRepository(),
@@ -82,6 +115,20 @@ func (c *Container) AuthService() *app.AuthService {
 	return c.authService
 }
 
+// DesignerService for who designs each game, through invitations
+func (c *Container) DesignerService() *app.DesignerService {
+	if c.designerService == nil {
+		c.designerService = &app.DesignerService{
+			DesignerRepository: c.DesignerRepository(),
+			GameRepository:     c.GameRepository(),
+			UserRepository:     c.UserRepository(),
+			Logger:             c.Logger(),
+		}
+	}
+
+	return c.designerService
+}
+
 // EventService for general event content interaction
 func (c *Container) EventService() *app.EventService {
 	if c.eventService == nil {
@@ -95,6 +142,20 @@ func (c *Container) EventService() *app.EventService {
 	return c.eventService
 }
 
+// FeedbackService for collecting and reporting playtest feedback
+func (c *Container) FeedbackService() *app.FeedbackService {
+	if c.feedbackService == nil {
+		c.feedbackService = &app.FeedbackService{
+			FeedbackRepository: c.FeedbackRepository(),
+			PlaytestRepository: c.PlaytestRepository(),
+			UserRepository:     c.UserRepository(),
+			Logger:             c.Logger(),
+		}
+	}
+
+	return c.feedbackService
+}
+
 // FileService for handling file uploads/downloads/etc
 func (c *Container) FileService() *app.FileService {
 	if c.fileService == nil {
@@ -115,9 +176,12 @@ func (c *Container) FileService() *app.FileService {
 func (c *Container) GameService() *app.GameService {
 	if c.gameService == nil {
 		c.gameService = &app.GameService{
-			GameRepository: c.GameRepository(),
-			UserRepository: c.UserRepository(),
-			Logger:         c.Logger(),
+			GameRepository:       c.GameRepository(),
+			MechanicRepository:   c.MechanicRepository(),
+			PublisherRepository:  c.PublisherRepository(),
+			SubmissionRepository: c.SubmissionRepository(),
+			UserRepository:       c.UserRepository(),
+			Logger:               c.Logger(),
 		}
 	}
 
@@ -138,10 +202,24 @@ func (c *Container) MailService() *app.MailService {
 	return c.mailService
 }
 
+// MechanicService for curating the mechanics taxonomy
+func (c *Container) MechanicService() *app.MechanicService {
+	if c.mechanicService == nil {
+		c.mechanicService = &app.MechanicService{
+			MechanicRepository: c.MechanicRepository(),
+			UserRepository:     c.UserRepository(),
+			Logger:             c.Logger(),
+		}
+	}
+
+	return c.mechanicService
+}
+
 // PlaytestService for general playtest content interaction
 func (c *Container) PlaytestService() *app.PlaytestService {
 	if c.playtestService == nil {
 		c.playtestService = &app.PlaytestService{
+			CreditRepository:   c.CreditRepository(),
 			EventRepository:    c.EventRepository(),
 			GameRepository:     c.GameRepository(),
 			PlaytestRepository: c.PlaytestRepository(),
@@ -153,12 +231,30 @@ func (c *Container) PlaytestService() *app.PlaytestService {
 	return c.playtestService
 }
 
+// PublisherService for publishers and the games submitted to them
+func (c *Container) PublisherService() *app.PublisherService {
+	if c.publisherService == nil {
+		c.publisherService = &app.PublisherService{
+			FileRepository:       c.FileRepository(),
+			GameRepository:       c.GameRepository(),
+			MechanicRepository:   c.MechanicRepository(),
+			PublisherRepository:  c.PublisherRepository(),
+			SubmissionRepository: c.SubmissionRepository(),
+			UserRepository:       c.UserRepository(),
+			Logger:               c.Logger(),
+		}
+	}
+
+	return c.publisherService
+}
+
 // UserService for general user content interaction
 func (c *Container) UserService() *app.UserService {
 	if c.userService == nil {
 		c.userService = &app.UserService{
-			UserRepository: c.UserRepository(),
-			Logger:         c.Logger(),
+			PlaytestRepository: c.PlaytestRepository(),
+			UserRepository:     c.UserRepository(),
+			Logger:             c.Logger(),
 		}
 	}
 
@@ -176,6 +272,39 @@ func (c *Container) EventRepository() domain.EventRepository {
 	return c.eventRepository
 }
 
+// CreditRepository implementation for database
+func (c *Container) CreditRepository() domain.CreditRepository {
+	if c.creditRepository == nil {
+		c.creditRepository = &persistence.CreditRepository{
+			DB: c.DB(),
+		}
+	}
+
+	return c.creditRepository
+}
+
+// DesignerRepository implementation for database
+func (c *Container) DesignerRepository() domain.DesignerRepository {
+	if c.designerRepository == nil {
+		c.designerRepository = &persistence.DesignerRepository{
+			DB: c.DB(),
+		}
+	}
+
+	return c.designerRepository
+}
+
+// FeedbackRepository implementation for database
+func (c *Container) FeedbackRepository() domain.FeedbackRepository {
+	if c.feedbackRepository == nil {
+		c.feedbackRepository = &persistence.FeedbackRepository{
+			DB: c.DB(),
+		}
+	}
+
+	return c.feedbackRepository
+}
+
 // FileRepository implementation for database
 func (c *Container) FileRepository() domain.FileRepository {
 	if c.fileRepository == nil {
@@ -209,6 +338,17 @@ func (c *Container) LoginAttemptRepository() domain.LoginAttemptRepository {
 	return c.loginAttemptRepository
 }
 
+// MechanicRepository implementation for database
+func (c *Container) MechanicRepository() domain.MechanicRepository {
+	if c.mechanicRepository == nil {
+		c.mechanicRepository = &persistence.MechanicRepository{
+			DB: c.DB(),
+		}
+	}
+
+	return c.mechanicRepository
+}
+
 // PlaytestRepository implementation for database
 func (c *Container) PlaytestRepository() domain.PlaytestRepository {
 	if c.playtestRepository == nil {
@@ -220,6 +360,28 @@ func (c *Container) PlaytestRepository() domain.PlaytestRepository {
 	return c.playtestRepository
 }
 
+// PublisherRepository implementation for database
+func (c *Container) PublisherRepository() domain.PublisherRepository {
+	if c.publisherRepository == nil {
+		c.publisherRepository = &persistence.PublisherRepository{
+			DB: c.DB(),
+		}
+	}
+
+	return c.publisherRepository
+}
+
+// SubmissionRepository implementation for database
+func (c *Container) SubmissionRepository() domain.SubmissionRepository {
+	if c.submissionRepository == nil {
+		c.submissionRepository = &persistence.SubmissionRepository{
+			DB: c.DB(),
+		}
+	}
+
+	return c.submissionRepository
+}
+
 // UserRepository implementation for database
 func (c *Container) UserRepository() domain.UserRepository {
 	if c.userRepository == nil {
@@ -244,6 +406,13 @@ func (c *Container) DB() *gorm.DB {
 			os.Getenv("DB_SSLMODE"),
 		)
 
+		// Sensitive columns are encrypted, so the key has to be ready before anything is read or written
+		cipher, err := secret.NewCipher(os.Getenv("ENCRYPTION_KEY"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		secret.Instance = cipher
+
 		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 		if err != nil {
 			log.Fatal(err)
@@ -254,11 +423,43 @@ func (c *Container) DB() *gorm.DB {
 			&domain.Game{},
 			&domain.User{},
 			&game.RulesSection{},
+			&domain.GameVersion{},
+			&domain.StatusChange{},
+			&domain.DesignerInvitation{},
+			&domain.DesignerChange{},
+			&game.VersionedSection{},
+			&domain.RulesRevision{},
 			&domain.Event{},
 			&domain.Playtest{},
+			&domain.Feedback{},
+			&domain.WaitlistEntry{},
+			&domain.CreditTransaction{},
+			&domain.AttendanceRecord{},
+			&domain.SeatResult{},
 			&domain.LoginAttempt{},
+			&domain.Mechanic{},
+			&domain.Publisher{},
+			&domain.Submission{},
+			&domain.SubmissionNote{},
+			&persistence.GameSearch{},
 		)
 
+		if err := persistence.SeedMechanics(db); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := persistence.AssignGameOwners(db); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := persistence.AssignPublisherOwners(db); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := persistence.IndexGamesForSearch(db); err != nil {
+			log.Fatal(err)
+		}
+
 		c.db = db
 	}
 
@@ -366,8 +567,16 @@ func (c *Container) Templates() map[string]*template.Template {
 
 		basePath := "ui/template/"
 		paths := []string{
+			"email/designer-invited",
+			"email/game-status-changed",
+			"email/invitation-answered",
+			"email/playtest-cancelled",
+			"email/playtest-rescheduled",
 			"email/reset-password",
+			"email/submission-received",
+			"email/submission-updated",
 			"email/verify-email",
+			"email/waitlist-promoted",
 			"email/welcome",
 		}
 		for _, p := range paths {
@@ -385,6 +594,17 @@ func (c *Container) Templates() map[string]*template.Template {
 	return c.templates
 }
 
+// AnalyticsController for handling /games/:id/analytics routes
+func (c *Container) AnalyticsController() *controller.AnalyticsController {
+	if c.analyticsController == nil {
+		c.analyticsController = &controller.AnalyticsController{
+			AnalyticsService: c.AnalyticsService(),
+		}
+	}
+
+	return c.analyticsController
+}
+
 // AuthController for handling /auth routes
 func (c *Container) AuthController() *controller.AuthController {
 	if c.authController == nil {
@@ -396,6 +616,17 @@ func (c *Container) AuthController() *controller.AuthController {
 	return c.authController
 }
 
+// DesignerController for handling the routes for who designs each game, and /invitations routes
+func (c *Container) DesignerController() *controller.DesignerController {
+	if c.designerController == nil {
+		c.designerController = &controller.DesignerController{
+			DesignerService: c.DesignerService(),
+		}
+	}
+
+	return c.designerController
+}
+
 // EventController for handling /events routes
 func (c *Container) EventController() *controller.EventController {
 	if c.eventController == nil {
@@ -407,6 +638,17 @@ func (c *Container) EventController() *controller.EventController {
 	return c.eventController
 }
 
+// FeedbackController for handling /playtests/:id/feedback routes
+func (c *Container) FeedbackController() *controller.FeedbackController {
+	if c.feedbackController == nil {
+		c.feedbackController = &controller.FeedbackController{
+			FeedbackService: c.FeedbackService(),
+		}
+	}
+
+	return c.feedbackController
+}
+
 // FileController for handling /files routes
 func (c *Container) FileController() *controller.FileController {
 	if c.fileController == nil {
@@ -429,17 +671,40 @@ func (c *Container) GameController() *controller.GameController {
 	return c.gameController
 }
 
+// MechanicController for handling /mechanics routes
+func (c *Container) MechanicController() *controller.MechanicController {
+	if c.mechanicController == nil {
+		c.mechanicController = &controller.MechanicController{
+			MechanicService: c.MechanicService(),
+		}
+	}
+
+	return c.mechanicController
+}
+
 // PlaytestController for handling /playtests routes
 func (c *Container) PlaytestController() *controller.PlaytestController {
 	if c.playtestController == nil {
 		c.playtestController = &controller.PlaytestController{
 			PlaytestService: c.PlaytestService(),
+			Board:           c.PlaytestBoard(),
 		}
 	}
 
 	return c.playtestController
 }
 
+// PublisherController for handling /publishers and /submissions routes
+func (c *Container) PublisherController() *controller.PublisherController {
+	if c.publisherController == nil {
+		c.publisherController = &controller.PublisherController{
+			PublisherService: c.PublisherService(),
+		}
+	}
+
+	return c.publisherController
+}
+
 // UserController for handling /users routes
 func (c *Container) UserController() *controller.UserController {
 	if c.userController == nil {
@@ -465,9 +730,19 @@ func (c *Container) EventHandler() *events.EventHandler {
 	if c.eventHandler == nil {
 		c.eventHandler = &events.EventHandler{
 			MailService: c.MailService(),
+			Board:       c.PlaytestBoard(),
 			Logger:      c.Logger(),
 		}
 	}
 
 	return c.eventHandler
 }
+
+// PlaytestBoard for streaming playtest changes to anyone watching an event night
+func (c *Container) PlaytestBoard() *events.PlaytestBoard {
+	if c.board == nil {
+		c.board = &events.PlaytestBoard{}
+	}
+
+	return c.board
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"gorm.io/gorm"
+)
+
+type CreditRepository struct {
+	DB *gorm.DB
+}
+
+func (r *CreditRepository) BalanceOf(userID uint) (int, error) {
+	return balanceOf(r.DB, userID)
+}
+
+// TransactionsOf lists a user's credit transactions, most recent first
+func (r *CreditRepository) TransactionsOf(userID uint, limit, offset int) ([]domain.CreditTransaction, int, error) {
+	transactions := []domain.CreditTransaction{}
+
+	var total int64
+	result := r.DB.Model(&domain.CreditTransaction{}).
+		Where("user_id = ?", userID).
+		Count(&total).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions)
+
+	if result.Error != nil {
+		return []domain.CreditTransaction{}, 0, result.Error
+	}
+
+	return transactions, int(total), nil
+}
+
+// TransactionsOfPlaytest lists every credit transaction tied to a playtest, oldest first
+func (r *CreditRepository) TransactionsOfPlaytest(playtestID uint) ([]domain.CreditTransaction, error) {
+	transactions := []domain.CreditTransaction{}
+
+	result := r.DB.Where("playtest_id = ?", playtestID).Order("created_at ASC, id ASC").Find(&transactions)
+	if result.Error != nil {
+		return []domain.CreditTransaction{}, result.Error
+	}
+
+	return transactions, nil
+}
+
+// Save will record a credit transaction. The ledger is append-only, so transactions are never updated.
+func (r *CreditRepository) Save(transaction *domain.CreditTransaction) error {
+	return r.DB.Create(transaction).Error
+}
+
+func balanceOf(db *gorm.DB, userID uint) (int, error) {
+	var balance int
+	result := db.Model(&domain.CreditTransaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ?", userID).
+		Scan(&balance)
+
+	return balance, result.Error
+}
@@ -0,0 +1,159 @@
+package persistence
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DesignerRepository struct {
+	DB *gorm.DB
+}
+
+// InvitationsOfGame lists every invitation to co-design a game, newest first
+func (r *DesignerRepository) InvitationsOfGame(gameID uint) ([]domain.DesignerInvitation, error) {
+	invitations := []domain.DesignerInvitation{}
+
+	result := r.invitations().
+		Where("designer_invitations.game_id = ?", gameID).
+		Order("designer_invitations.id DESC").
+		Find(&invitations)
+
+	if result.Error != nil {
+		return []domain.DesignerInvitation{}, result.Error
+	}
+
+	return invitations, nil
+}
+
+// InvitationsForUser lists the invitations waiting on the user's answer, whether they were sent to the user
+// directly or to their email before they signed up. Invitations sent to an email are only included once the
+// user has verified it.
+func (r *DesignerRepository) InvitationsForUser(user *domain.User) ([]domain.DesignerInvitation, error) {
+	invitations := []domain.DesignerInvitation{}
+
+	recipient := r.DB.Where("designer_invitations.invitee_id = ?", user.ID)
+	if user.Account.Verified {
+		recipient = recipient.Or("designer_invitations.invitee_id IS NULL AND lower(designer_invitations.email) = lower(?)", user.Account.Email)
+	}
+
+	result := r.invitations().
+		Where("designer_invitations.status = ?", game.InvitationPending).
+		Where(recipient).
+		Order("designer_invitations.id ASC").
+		Find(&invitations)
+
+	if result.Error != nil {
+		return []domain.DesignerInvitation{}, result.Error
+	}
+
+	return invitations, nil
+}
+
+func (r *DesignerRepository) InvitationOfID(id uint) (*domain.DesignerInvitation, error) {
+	invitation := &domain.DesignerInvitation{}
+	result := r.invitations().First(invitation, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return invitation, nil
+}
+
+// DesignerHistory lists every change to who designs a game, oldest first
+func (r *DesignerRepository) DesignerHistory(gameID uint) ([]domain.DesignerChange, error) {
+	changes := []domain.DesignerChange{}
+
+	result := r.DB.
+		Preload("Designer").
+		Preload("ChangedBy").
+		Where("game_id = ?", gameID).
+		Order("designer_changes.id ASC").
+		Find(&changes)
+
+	if result.Error != nil {
+		return []domain.DesignerChange{}, result.Error
+	}
+
+	return changes, nil
+}
+
+// SaveInvitation stores the invitation and records the change. Accepted invitations add the invitee to the
+// game's designers at the same time.
+func (r *DesignerRepository) SaveInvitation(invitation *domain.DesignerInvitation, change *domain.DesignerChange) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		var err error
+		if invitation.ID != 0 {
+			err = db.Omit(clause.Associations).Save(invitation).Error
+		} else {
+			err = db.Omit(clause.Associations).Create(invitation).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if invitation.Status == game.InvitationAccepted {
+			err = db.Model(&invitation.Game).Association("Designers").Append(invitation.Invitee)
+			if err != nil {
+				return err
+			}
+
+			if err := indexGame(db, invitation.GameID); err != nil {
+				return err
+			}
+		}
+
+		return db.Omit(clause.Associations).Create(change).Error
+	})
+}
+
+// SaveDesigners stores who designs and owns the game, and records the change
+func (r *DesignerRepository) SaveDesigners(g *domain.Game, change *domain.DesignerChange) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := db.Model(g).Association("Designers").Replace(g.Designers)
+		if err != nil {
+			return err
+		}
+
+		err = db.Model(g).UpdateColumn("owner_id", g.OwnerID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := indexGame(db, g.ID); err != nil {
+			return err
+		}
+
+		return db.Omit(clause.Associations).Create(change).Error
+	})
+}
+
+// invitations preloads everything needed to show and answer an invitation
+func (r *DesignerRepository) invitations() *gorm.DB {
+	return r.DB.Model(&domain.DesignerInvitation{}).
+		Preload("Game").
+		Preload("Game.Designers").
+		Preload("InvitedBy").
+		Preload("Invitee")
+}
+
+// AssignGameOwners makes the designer with the oldest account the owner of any game without one, such as
+// those created before games had owners
+func AssignGameOwners(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE games
+		SET owner_id = (
+			SELECT min(game_designers.user_id)
+			FROM game_designers
+			WHERE game_designers.game_id = games.id
+		)
+		WHERE games.owner_id = 0
+		AND EXISTS (SELECT 1 FROM game_designers WHERE game_designers.game_id = games.id)`,
+	).Error
+}
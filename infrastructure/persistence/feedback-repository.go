@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeedbackRepository struct {
+	DB *gorm.DB
+}
+
+func (r *FeedbackRepository) FeedbackOfPlaytest(playtestID uint) ([]domain.Feedback, error) {
+	responses := []domain.Feedback{}
+	result := r.DB.Preload("Player").Order("feedbacks.created_at ASC").Find(&responses, "playtest_id = ?", playtestID)
+
+	return responses, result.Error
+}
+
+func (r *FeedbackRepository) FeedbackOfPlayer(playtestID, playerID uint) (*domain.Feedback, error) {
+	response := &domain.Feedback{}
+	result := r.DB.Preload("Player").First(response, "playtest_id = ? AND player_id = ?", playtestID, playerID)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return response, nil
+}
+
+// Save will upsert a feedback record. Players only get one response per playtest, so a new response
+// from someone who already answered replaces their earlier one.
+func (r *FeedbackRepository) Save(response *domain.Feedback) error {
+	var result *gorm.DB
+	if response.ID != 0 {
+		result = r.DB.Omit("Playtest", "Player").Save(response)
+	} else {
+		result = r.DB.Omit("Playtest", "Player").Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "playtest_id"}, {Name: "player_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at",
+				"rating_fun",
+				"rating_clarity",
+				"rating_pacing",
+				"rating_replayability",
+				"rating_overall",
+				"comments",
+				"hoping_to_test_answer",
+			}),
+		}).Create(response)
+	}
+
+	return result.Error
+}
@@ -14,15 +14,15 @@ type GameRepository struct {
 	DB *gorm.DB
 }
 
-func (r *GameRepository) ListGames(title, status, designer string, owner uint, playerCount, age, playtime, limit, offset int, sort string) ([]domain.Game, int, error) {
+func (r *GameRepository) ListGames(search, title, status, designer string, owner uint, playerCount, age, playtime, limit, offset int, sort string) ([]domain.Game, int, error) {
 	games := []domain.Game{}
 
 	// Setup query
-	query := r.DB.Model(&domain.Game{}).Preload("Designers").Preload("Files", func(db *gorm.DB) *gorm.DB {
+	query := r.DB.Model(&domain.Game{}).Preload("Designers").Preload("Publisher").Preload("Files", func(db *gorm.DB) *gorm.DB {
 		return db.Where("files.role = 'Image'").Order("files.order_by ASC")
 	})
 
-	// Set order
+	// Set order. Searches are ranked by relevance unless a sort was asked for.
 	sortCol := "games.updated_at"
 	sortDir := "desc"
 	if sort != "" {
@@ -35,9 +35,22 @@ func (r *GameRepository) ListGames(title, status, designer string, owner uint, p
 		}
 	}
 
-	query = query.Order(sortCol + " " + sortDir)
+	if search != "" && sort == "" {
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank_cd(game_searches.document, " + searchQuery + ") DESC, games.updated_at DESC",
+			Vars: []interface{}{search},
+		}})
+	} else {
+		query = query.Order(sortCol + " " + sortDir)
+	}
 
 	// Apply filters
+	if search != "" {
+		query = query.
+			Joins("JOIN game_searches ON game_searches.game_id = games.id").
+			Where("game_searches.document @@ "+searchQuery, search)
+	}
+
 	if title != "" {
 		query = query.Where("games.title % ?", title)
 	}
@@ -88,7 +101,9 @@ func (r *GameRepository) ListGames(title, status, designer string, owner uint, p
 
 func (r *GameRepository) GameOfID(id uint) (*domain.Game, error) {
 	game := &domain.Game{}
-	result := r.DB.Preload(clause.Associations).Preload("Files", func(db *gorm.DB) *gorm.DB {
+	result := r.DB.Preload(clause.Associations).Preload("Rules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("rules_sections.order_by ASC, rules_sections.id ASC")
+	}).Preload("Files", func(db *gorm.DB) *gorm.DB {
 		return db.Order("files.order_by ASC")
 	}).First(game, id)
 
@@ -106,7 +121,206 @@ func (r *GameRepository) GameOfID(id uint) (*domain.Game, error) {
 func (r *GameRepository) RulesOfGame(id uint) ([]game.RulesSection, error) {
 	rules := []game.RulesSection{}
 
-	result := r.DB.Find(&rules).Where("game_id = ?", id).Order("rules_section.order_by ASC")
+	result := r.DB.Where("game_id = ?", id).Order("rules_sections.order_by ASC, rules_sections.id ASC").Find(&rules)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return rules, nil
+}
+
+// SaveRulesSection will upsert a single rules section, along with the revision recording the change
+func (r *GameRepository) SaveRulesSection(section *game.RulesSection, revision *domain.RulesRevision) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := db.Save(section).Error
+		if err != nil {
+			return err
+		}
+
+		// New sections only have an ID now
+		revision.SectionID = section.ID
+
+		err = db.Omit("Author").Create(revision).Error
+		if err != nil {
+			return err
+		}
+
+		return indexGame(db, section.GameID)
+	})
+}
+
+// DeleteRulesSection will remove a rules section for good. Its revisions are kept, so it can be restored.
+func (r *GameRepository) DeleteRulesSection(section *game.RulesSection, revision *domain.RulesRevision) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := db.Delete(section).Error
+		if err != nil {
+			return err
+		}
+
+		err = db.Omit("Author").Create(revision).Error
+		if err != nil {
+			return err
+		}
+
+		return indexGame(db, section.GameID)
+	})
+}
+
+// ReorderRules stores the new order of every section at once, so the rules are never left half-sorted
+func (r *GameRepository) ReorderRules(rules []game.RulesSection, revisions []domain.RulesRevision) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		for _, section := range rules {
+			err := db.Model(&game.RulesSection{}).
+				Where("id = ? AND game_id = ?", section.ID, section.GameID).
+				UpdateColumn("order_by", section.OrderBy).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(revisions) == 0 {
+			return nil
+		}
+
+		return db.Omit("Author").Create(&revisions).Error
+	})
+}
+
+// ApplyRulesChanges saves or deletes several sections at once, along with their revisions, so an import
+// either lands completely or not at all
+func (r *GameRepository) ApplyRulesChanges(changes []domain.RulesChange) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		for _, change := range changes {
+			var err error
+			if change.Revision.Removed {
+				err = db.Delete(change.Section).Error
+			} else {
+				err = db.Save(change.Section).Error
+			}
+
+			if err != nil {
+				return err
+			}
+
+			// New sections only have an ID now
+			change.Revision.SectionID = change.Section.ID
+
+			err = db.Omit("Author").Create(change.Revision).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		return indexGame(db, changes[0].Section.GameID)
+	})
+}
+
+// RewriteContent runs every stored overview and piece of rules content, including past revisions and
+// versions, through the rewrite function, saving any that change. It returns how many rows were changed.
+func (r *GameRepository) RewriteContent(rewrite func(string) string) (int, error) {
+	columns := []struct{ table, column string }{
+		{"games", "overview"},
+		{"rules_sections", "content"},
+		{"rules_revisions", "content"},
+		{"versioned_sections", "content"},
+	}
+
+	changed := 0
+	for _, c := range columns {
+		n, err := r.rewriteColumn(c.table, c.column, rewrite)
+		changed += n
+		if err != nil {
+			return changed, err
+		}
+	}
+
+	if changed == 0 {
+		return 0, nil
+	}
+
+	return changed, indexGames(r.DB, "TRUE")
+}
+
+// rewriteColumn works through a table in batches, so large tables never need to be loaded at once
+func (r *GameRepository) rewriteColumn(table, column string, rewrite func(string) string) (int, error) {
+	type row struct {
+		ID    uint
+		Value string
+	}
+
+	changed := 0
+	lastID := uint(0)
+	for {
+		rows := []row{}
+		err := r.DB.Table(table).
+			Select("id, "+column+" AS value").
+			Where("id > ? AND "+column+" <> ''", lastID).
+			Order("id ASC").
+			Limit(100).
+			Scan(&rows).Error
+		if err != nil {
+			return changed, err
+		}
+
+		if len(rows) == 0 {
+			return changed, nil
+		}
+
+		for _, rw := range rows {
+			value := rewrite(rw.Value)
+			if value == rw.Value {
+				continue
+			}
+
+			err := r.DB.Table(table).Where("id = ?", rw.ID).UpdateColumn(column, value).Error
+			if err != nil {
+				return changed, err
+			}
+			changed++
+		}
+
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// RevisionsOfGame lists the revisions of a game's rules, newest first, optionally for a single section.
+// A limit of -1 returns every matching revision.
+func (r *GameRepository) RevisionsOfGame(gameID, sectionID uint, limit, offset int) ([]domain.RulesRevision, int, error) {
+	revisions := []domain.RulesRevision{}
+
+	query := r.DB.Model(&domain.RulesRevision{}).
+		Preload("Author").
+		Where("rules_revisions.game_id = ?", gameID).
+		Order("rules_revisions.id DESC")
+
+	if sectionID != 0 {
+		query = query.Where("rules_revisions.section_id = ?", sectionID)
+	}
+
+	var total int64
+	result := query.
+		Count(&total).
+		Limit(limit).
+		Offset(offset).
+		Find(&revisions)
+
+	if result.Error != nil {
+		return []domain.RulesRevision{}, 0, result.Error
+	}
+
+	return revisions, int(total), nil
+}
+
+// RevisionOfID pulls up a single revision of any game's rules, along with its author. Callers check that it
+// belongs to the game they're working on.
+func (r *GameRepository) RevisionOfID(id uint) (*domain.RulesRevision, error) {
+	revision := &domain.RulesRevision{}
+	result := r.DB.Preload("Author").First(revision, id)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -116,7 +330,76 @@ func (r *GameRepository) RulesOfGame(id uint) ([]game.RulesSection, error) {
 		return nil, result.Error
 	}
 
-	return rules, nil
+	return revision, nil
+}
+
+// VersionsOfGame lists a game's versions, newest first. Rules and files are left out to keep the listing light.
+func (r *GameRepository) VersionsOfGame(id uint) ([]domain.GameVersion, error) {
+	versions := []domain.GameVersion{}
+
+	result := r.DB.Where("game_id = ?", id).Order("game_versions.created_at DESC").Find(&versions)
+	if result.Error != nil {
+		return []domain.GameVersion{}, result.Error
+	}
+
+	return versions, nil
+}
+
+func (r *GameRepository) VersionOfID(id uint) (*domain.GameVersion, error) {
+	version := &domain.GameVersion{}
+	result := r.DB.Preload("Rules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("versioned_sections.order_by ASC, versioned_sections.id ASC")
+	}).Preload("Files", func(db *gorm.DB) *gorm.DB {
+		return db.Order("files.order_by ASC")
+	}).First(version, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return version, nil
+}
+
+// SaveVersion stores a new snapshot of the game and pins it as the game's current version
+func (r *GameRepository) SaveVersion(game *domain.Game, version *domain.GameVersion) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		return saveVersion(db, game, version)
+	})
+}
+
+// saveVersion stores the version and pins it as the game's current version, as part of a larger transaction
+func saveVersion(db *gorm.DB, game *domain.Game, version *domain.GameVersion) error {
+	err := db.Omit("Files.*").Create(version).Error
+	if err != nil {
+		return err
+	}
+
+	game.PinVersion(version)
+
+	return db.Model(game).UpdateColumn("current_version_id", game.CurrentVersionID).Error
+}
+
+// StatusHistory lists every status change of a game, oldest first
+func (r *GameRepository) StatusHistory(gameID uint) ([]domain.StatusChange, error) {
+	changes := []domain.StatusChange{}
+
+	result := r.DB.Preload("ChangedBy").Preload("Publisher").Where("game_id = ?", gameID).Order("status_changes.id ASC").Find(&changes)
+	if result.Error != nil {
+		return []domain.StatusChange{}, result.Error
+	}
+
+	return changes, nil
+}
+
+// SaveStatusChange stores the game's new status, along with its contract details, and records the change
+func (r *GameRepository) SaveStatusChange(game *domain.Game, change *domain.StatusChange) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		return saveStatusChange(db, game, change)
+	})
 }
 
 // Save will upsert a game record
@@ -125,16 +408,25 @@ func (r *GameRepository) Save(game *domain.Game) error {
 
 		var result *gorm.DB
 		if game.ID != 0 {
-			err := db.Model(game).Association("Designers").Replace(game.Designers)
-			if err != nil {
-				return err
-			}
-
-			result = db.Omit("Designers").Save(game)
+			// Designers and ownership only change through invitations and transfers, see DesignerRepository
+			result = db.Omit("Designers", "OwnerID", "Publisher").Save(game)
 		} else {
-			result = db.Omit("Designers.*").Create(game)
+			result = db.Omit("Designers.*", "Publisher").Create(game)
+		}
+
+		if result.Error != nil {
+			return result.Error
 		}
 
-		return result.Error
+		return indexGame(db, game.ID)
 	})
 }
+
+func saveStatusChange(db *gorm.DB, game *domain.Game, change *domain.StatusChange) error {
+	err := db.Model(game).Select("status", "publisher_id", "contract_date").Updates(game).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Omit("ChangedBy", "Publisher").Create(change).Error
+}
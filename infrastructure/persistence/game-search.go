@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"html"
+	"strings"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"gorm.io/gorm"
+)
+
+// GameSearch holds the full-text search document for a game. Titles weigh the most, followed by mechanics and
+// designer names, then the overview and finally the rules. Documents are rebuilt whenever any of those change.
+type GameSearch struct {
+	GameID   uint   `gorm:"primarykey;autoIncrement:false"`
+	Document string `gorm:"type:tsvector;index:,type:gin"`
+}
+
+// searchQuery parses what people type into a search box, quotes and -exclusions included
+const searchQuery = "websearch_to_tsquery('english', ?)"
+
+// rulesText and designersText gather text from related tables for the game in the outer query
+const rulesText = `(
+	SELECT string_agg(rules_sections.title || ' ' || regexp_replace(rules_sections.content, '<[^>]*>', ' ', 'g'), ' ')
+	FROM rules_sections
+	WHERE rules_sections.game_id = games.id
+)`
+
+const designersText = `(
+	SELECT string_agg(users.name, ' ')
+	FROM game_designers
+	JOIN users ON users.id = game_designers.user_id
+	WHERE game_designers.game_id = games.id
+)`
+
+var markTags = strings.NewReplacer("&lt;mark&gt;", "<mark>", "&lt;/mark&gt;", "</mark>")
+
+// indexGame rebuilds a single game's search document
+func indexGame(db *gorm.DB, gameID uint) error {
+	return indexGames(db, "games.id = ?", gameID)
+}
+
+// indexGames rebuilds the search documents of the games matching the condition
+func indexGames(db *gorm.DB, condition string, args ...interface{}) error {
+	return db.Exec(indexGamesSQL(condition), args...).Error
+}
+
+// indexGamesSQL builds the statement that writes search documents for the games matching the condition
+func indexGamesSQL(condition string) string {
+	return `
+		INSERT INTO game_searches (game_id, document)
+		SELECT games.id,
+			setweight(to_tsvector('english', coalesce(games.title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(array_to_string(games.mechanics, ' '), '')), 'B') ||
+			setweight(to_tsvector('english', coalesce(` + designersText + `, '')), 'B') ||
+			setweight(to_tsvector('english', regexp_replace(coalesce(games.overview, ''), '<[^>]*>', ' ', 'g')), 'C') ||
+			setweight(to_tsvector('english', coalesce(` + rulesText + `, '')), 'D')
+		FROM games
+		WHERE ` + condition + `
+		ON CONFLICT (game_id) DO UPDATE SET document = excluded.document`
+}
+
+// IndexGamesForSearch builds search documents for any games that don't have one yet, such as those created
+// before search was introduced
+func IndexGamesForSearch(db *gorm.DB) error {
+	return indexGames(db, "games.id NOT IN (SELECT game_id FROM game_searches)")
+}
+
+// HighlightGames shows where each of the games matched the search. Only the parts of the overview and rules
+// around the matching words are included.
+func (r *GameRepository) HighlightGames(ids []uint, search string) ([]game.Highlight, error) {
+	highlights := []game.Highlight{}
+	if len(ids) == 0 || search == "" {
+		return highlights, nil
+	}
+
+	const options = "'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=8'"
+
+	result := r.DB.Raw(`
+		SELECT games.id AS game_id,
+			ts_headline('english', games.title, query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS title,
+			ts_headline('english', regexp_replace(coalesce(games.overview, ''), '<[^>]*>', ' ', 'g'), query, `+options+`) AS overview,
+			CASE WHEN to_tsvector('english', coalesce(`+rulesText+`, '')) @@ query
+				THEN ts_headline('english', `+rulesText+`, query, `+options+`)
+				ELSE ''
+			END AS rules
+		FROM games, `+searchQuery+` query
+		WHERE games.id IN ?`,
+		search, ids,
+	).Scan(&highlights)
+
+	if result.Error != nil {
+		return []game.Highlight{}, result.Error
+	}
+
+	for i := range highlights {
+		highlights[i].Title = highlightTitle(highlights[i].Title)
+		highlights[i].Overview = highlightSnippet(highlights[i].Overview)
+		highlights[i].Rules = highlightSnippet(highlights[i].Rules)
+	}
+
+	return highlights, nil
+}
+
+// highlightTitle escapes a highlighted title for HTML. Titles are plain text rather than HTML, so everything but
+// the highlight marks needs escaping.
+func highlightTitle(title string) string {
+	return markTags.Replace(html.EscapeString(title))
+}
+
+// highlightSnippet escapes a highlighted overview or rules snippet for HTML. Snippets are gathered from HTML with
+// its tags stripped, but rules section titles are plain text and can hold anything, so the whole snippet is
+// escaped afresh and only the highlight marks are kept.
+func highlightSnippet(snippet string) string {
+	return highlightTitle(html.UnescapeString(snippet))
+}
@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightTitle(t *testing.T) {
+	var tests = []struct {
+		title    string
+		expected string
+	}{
+		{"Snail <mark>Race</mark>", "Snail <mark>Race</mark>"},
+		{"<b>Snail</b> & <mark>Race</mark>", "&lt;b&gt;Snail&lt;/b&gt; &amp; <mark>Race</mark>"},
+		{"<mark>Snails</mark> \"R\" Us", "<mark>Snails</mark> &#34;R&#34; Us"},
+		{"No matches", "No matches"},
+	}
+
+	for _, tt := range tests {
+		actual := highlightTitle(tt.title)
+		if actual != tt.expected {
+			t.Errorf("Highlighting %q: expected %q, got %q", tt.title, tt.expected, actual)
+		}
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	var tests = []struct {
+		snippet  string
+		expected string
+	}{
+		{"Deal <mark>five</mark> cards", "Deal <mark>five</mark> cards"},
+		{"Fish &amp; Chips <mark>Setup</mark>", "Fish &amp; Chips <mark>Setup</mark>"},
+		{"&lt;b&gt; is bold", "&lt;b&gt; is bold"},
+		{"<img src=x onerror=alert(1)> <mark>Setup</mark> Deal", "&lt;img src=x onerror=alert(1)&gt; <mark>Setup</mark> Deal"},
+		{"<script>alert(1)</script>", "&lt;script&gt;alert(1)&lt;/script&gt;"},
+	}
+
+	for _, tt := range tests {
+		actual := highlightSnippet(tt.snippet)
+		if actual != tt.expected {
+			t.Errorf("Highlighting %q: expected %q, got %q", tt.snippet, tt.expected, actual)
+		}
+	}
+}
+
+func TestIndexGamesSQL(t *testing.T) {
+	sql := indexGamesSQL("games.id = ?")
+
+	for _, expected := range []string{
+		"setweight(to_tsvector('english', coalesce(games.title, '')), 'A')",
+		"setweight(to_tsvector('english', coalesce(array_to_string(games.mechanics, ' '), '')), 'B')",
+		"setweight(to_tsvector('english', coalesce(" + designersText + ", '')), 'B')",
+		"'C')",
+		"setweight(to_tsvector('english', coalesce(" + rulesText + ", '')), 'D')",
+		"WHERE games.id = ?",
+		"ON CONFLICT (game_id) DO UPDATE SET document = excluded.document",
+	} {
+		if !strings.Contains(sql, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, sql)
+		}
+	}
+
+	if strings.Count(sql, "?") != 1 {
+		t.Errorf("Expected the condition's placeholder to be the only one, got:\n%s", sql)
+	}
+}
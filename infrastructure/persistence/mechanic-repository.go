@@ -0,0 +1,145 @@
+package persistence
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type MechanicRepository struct {
+	DB *gorm.DB
+}
+
+// AllMechanics lists the whole taxonomy, sorted by name
+func (r *MechanicRepository) AllMechanics() ([]domain.Mechanic, error) {
+	mechanics := []domain.Mechanic{}
+	result := r.DB.Order("mechanics.name ASC").Find(&mechanics)
+
+	return mechanics, result.Error
+}
+
+func (r *MechanicRepository) MechanicOfID(id uint) (*domain.Mechanic, error) {
+	mechanic := &domain.Mechanic{}
+	result := r.DB.First(mechanic, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return mechanic, nil
+}
+
+// MechanicUsage counts how many games are tagged with each mechanic, by name
+func (r *MechanicRepository) MechanicUsage() (map[string]int, error) {
+	rows := []struct {
+		Name  string
+		Games int
+	}{}
+
+	result := r.DB.Raw(`
+		SELECT mechanic AS name, count(*) AS games
+		FROM games, unnest(games.mechanics) AS mechanic
+		WHERE games.deleted_at IS NULL
+		GROUP BY mechanic`,
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	usage := map[string]int{}
+	for _, row := range rows {
+		usage[row.Name] = row.Games
+	}
+
+	return usage, nil
+}
+
+// Save will upsert a mechanic
+func (r *MechanicRepository) Save(mechanic *domain.Mechanic) error {
+	if mechanic.ID != 0 {
+		return r.DB.Save(mechanic).Error
+	}
+
+	return r.DB.Create(mechanic).Error
+}
+
+// Merge stores the target mechanic after it's absorbed the source, moves every game and child mechanic over to
+// the target and removes the source, all at once
+func (r *MechanicRepository) Merge(target, source *domain.Mechanic) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		// Games tagged with both only keep the target
+		err := db.Exec(`
+			UPDATE games
+			SET mechanics = CASE
+				WHEN ? = ANY(mechanics) THEN array_remove(mechanics, ?)
+				ELSE array_replace(mechanics, ?, ?)
+			END
+			WHERE ? = ANY(mechanics)`,
+			target.Name, source.Name, source.Name, target.Name, source.Name,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		err = db.Model(&domain.Mechanic{}).
+			Where("parent_id = ? AND id <> ?", source.ID, target.ID).
+			UpdateColumn("parent_id", target.ID).Error
+		if err != nil {
+			return err
+		}
+
+		err = db.Delete(source).Error
+		if err != nil {
+			return err
+		}
+
+		err = db.Save(target).Error
+		if err != nil {
+			return err
+		}
+
+		// Synonyms aren't searchable, but the retagged games' names are
+		return indexGames(db, "? = ANY(games.mechanics)", target.Name)
+	})
+}
+
+// SeedMechanics fills an empty taxonomy with the default mechanics, along with any others games are already
+// tagged with, so existing games stay valid
+func SeedMechanics(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&domain.Mechanic{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	var used pq.StringArray
+	err := db.Raw("SELECT coalesce(array_agg(DISTINCT mechanic), '{}') FROM games, unnest(games.mechanics) AS mechanic").
+		Row().
+		Scan(&used)
+	if err != nil {
+		return err
+	}
+
+	mechanics := []domain.Mechanic{}
+	slugs := map[string]bool{}
+	for _, name := range append(game.DefaultMechanics(), used...) {
+		slug := game.Slugify(name)
+		if slug == "" || slugs[slug] {
+			continue
+		}
+
+		slugs[slug] = true
+		mechanics = append(mechanics, domain.Mechanic{Slug: slug, Name: name, Synonyms: pq.StringArray{}})
+	}
+
+	return db.Create(&mechanics).Error
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/secret"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -12,20 +14,32 @@ type PlaytestRepository struct {
 	DB *gorm.DB
 }
 
-func (r *PlaytestRepository) PlaytestsOnDate(date time.Time, eventID uint) ([]domain.Playtest, error) {
+// PlaytestsBetween lists the playtests scheduled from start up to, but not including, end. Cancelled playtests
+// are left out unless asked for.
+func (r *PlaytestRepository) PlaytestsBetween(start, end time.Time, eventID uint, includeCancelled bool) ([]domain.Playtest, error) {
 	playtests := []domain.Playtest{}
 
 	query := r.DB.Model(&domain.Playtest{}).
 		Preload("Game").
 		Preload("Game.Designers").
 		Preload("Event").
+		Preload("Event.Facilitators").
 		Preload("Players").
-		Where("playtests.scheduled_date::date = ?::date", date)
+		Preload("Waitlist", orderedWaitlist).
+		Preload("Waitlist.User").
+		Preload("Attendance").
+		Preload("Attendance.User").
+		Where("playtests.scheduled_date >= ? AND playtests.scheduled_date < ?", start, end).
+		Order("playtests.scheduled_date, playtests.slot, playtests.id")
 
 	if eventID != 0 {
 		query = query.Where("playtests.event_id = ?", eventID)
 	}
 
+	if !includeCancelled {
+		query = query.Where("playtests.state IS DISTINCT FROM 'Cancelled'")
+	}
+
 	result := query.Find(&playtests)
 
 	if result.Error != nil {
@@ -35,9 +49,109 @@ func (r *PlaytestRepository) PlaytestsOnDate(date time.Time, eventID uint) ([]do
 	return playtests, nil
 }
 
+// PlaytestsOfGame lists a game's playtests scheduled from up to, but not including, to, most recent first.
+// Zero times leave that end of the range open.
+// A limit of -1 returns every matching playtest.
+func (r *PlaytestRepository) PlaytestsOfGame(gameID uint, from, to time.Time, eventID, versionID uint, limit, offset int) ([]domain.Playtest, int, error) {
+	playtests := []domain.Playtest{}
+
+	query := r.DB.Model(&domain.Playtest{}).
+		Preload("Game").
+		Preload("Game.Designers").
+		Preload("GameVersion").
+		Preload("Event").
+		Preload("Event.Facilitators").
+		Preload("Players").
+		Preload("Results", orderedResults).
+		Scopes(playtestsOfGame(gameID, from, to, eventID, versionID)).
+		Order("playtests.scheduled_date DESC")
+
+	var total int64
+	result := query.
+		Count(&total).
+		Limit(limit).
+		Offset(offset).
+		Find(&playtests)
+
+	if result.Error != nil {
+		return []domain.Playtest{}, 0, result.Error
+	}
+
+	return playtests, int(total), nil
+}
+
+// HistoryOfGame aggregates the same playtests PlaytestsOfGame would list, leaving out cancelled tests.
+// Durations are in minutes and only consider tests that have both started and finished, so the actual
+// and requested durations can be compared directly.
+func (r *PlaytestRepository) HistoryOfGame(gameID uint, from, to time.Time, eventID, versionID uint) (playtest.HistoryMetrics, error) {
+	metrics := playtest.HistoryMetrics{PlayerCounts: map[int]int{}}
+	matching := func(db *gorm.DB) *gorm.DB {
+		return db.Model(&domain.Playtest{}).
+			Scopes(playtestsOfGame(gameID, from, to, eventID, versionID)).
+			Where("playtests.state IS DISTINCT FROM 'Cancelled'")
+	}
+
+	var totals struct {
+		Tests                    int
+		AverageDuration          float64
+		AverageRequestedDuration float64
+	}
+
+	completed := "playtests.start_time IS NOT NULL AND playtests.end_time IS NOT NULL"
+	err := r.DB.Scopes(matching).
+		Select("COUNT(*) AS tests, " +
+			"COALESCE(AVG(EXTRACT(EPOCH FROM playtests.end_time - playtests.start_time) / 60) FILTER (WHERE " + completed + "), 0) AS average_duration, " +
+			"COALESCE(AVG(playtests.duration) FILTER (WHERE " + completed + "), 0) AS average_requested_duration").
+		Scan(&totals).Error
+	if err != nil {
+		return metrics, err
+	}
+
+	var testers int64
+	err = r.DB.Scopes(matching).
+		Joins("JOIN playtesters ON playtesters.playtest_id = playtests.id").
+		Select("COUNT(DISTINCT playtesters.user_id)").
+		Scan(&testers).Error
+	if err != nil {
+		return metrics, err
+	}
+
+	counts := []struct {
+		Players int
+		Tests   int
+	}{}
+	seated := r.DB.Scopes(matching).
+		Select("(SELECT COUNT(*) FROM playtesters WHERE playtesters.playtest_id = playtests.id) AS players")
+	err = r.DB.Table("(?) AS seated", seated).
+		Select("seated.players, COUNT(*) AS tests").
+		Group("seated.players").
+		Scan(&counts).Error
+	if err != nil {
+		return metrics, err
+	}
+
+	metrics.Tests = totals.Tests
+	metrics.DistinctTesters = int(testers)
+	metrics.AverageDuration = totals.AverageDuration
+	metrics.AverageRequestedDuration = totals.AverageRequestedDuration
+	for _, c := range counts {
+		metrics.PlayerCounts[c.Players] = c.Tests
+	}
+
+	return metrics, nil
+}
+
 func (r *PlaytestRepository) PlaytestOfID(id uint) (*domain.Playtest, error) {
 	playtest := &domain.Playtest{}
-	result := r.DB.Preload(clause.Associations).First(playtest, id)
+	result := r.DB.Preload(clause.Associations).
+		Preload("Game.Designers").
+		Preload("Event.Facilitators").
+		Preload("Waitlist", orderedWaitlist).
+		Preload("Waitlist.User").
+		Preload("Attendance.User").
+		Preload("Results", orderedResults).
+		Preload("Results.User").
+		First(playtest, id)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -50,22 +164,220 @@ func (r *PlaytestRepository) PlaytestOfID(id uint) (*domain.Playtest, error) {
 	return playtest, nil
 }
 
+// AttendanceOfUsers lists every attendance record for the given users, across all of their playtests
+func (r *PlaytestRepository) AttendanceOfUsers(userIDs []uint) ([]domain.AttendanceRecord, error) {
+	records := []domain.AttendanceRecord{}
+	if len(userIDs) == 0 {
+		return records, nil
+	}
+
+	result := r.DB.Where("user_id IN ?", userIDs).Find(&records)
+
+	return records, result.Error
+}
+
+// Register creates a new playtest along with whatever its registrant is charged for it. The registrant is
+// locked while their balance is read, so two registrations can't spend the same credits.
+func (r *PlaytestRepository) Register(playtest *domain.Playtest, snapshot *domain.GameVersion, registrantID uint, charge func(balance int) (*domain.CreditTransaction, error)) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&domain.User{}, registrantID).Error
+		if err != nil {
+			return err
+		}
+
+		balance, err := balanceOf(db, registrantID)
+		if err != nil {
+			return err
+		}
+
+		spent, err := charge(balance)
+		if err != nil {
+			return err
+		}
+
+		if snapshot != nil {
+			err = saveVersion(db, &domain.Game{ID: snapshot.GameID}, snapshot)
+			if err != nil {
+				return err
+			}
+
+			playtest.PinVersion(snapshot)
+		}
+
+		err = savePlaytest(db, playtest)
+		if err != nil {
+			return err
+		}
+
+		if spent == nil {
+			return nil
+		}
+
+		spent.ForPlaytest(playtest)
+
+		return db.Create(spent).Error
+	})
+}
+
 // Save will upsert an playtest record
 func (r *PlaytestRepository) Save(playtest *domain.Playtest) error {
 	return r.DB.Transaction(func(db *gorm.DB) error {
+		return savePlaytest(db, playtest)
+	})
+}
 
-		var result *gorm.DB
-		if playtest.ID != 0 {
-			err := db.Model(playtest).Association("Players").Replace(playtest.Players)
-			if err != nil {
+// SaveAll will upsert several playtest records at once, so either every one of them is saved or none are
+func (r *PlaytestRepository) SaveAll(playtests []domain.Playtest) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		for i := range playtests {
+			if err := savePlaytest(db, &playtests[i]); err != nil {
 				return err
 			}
+		}
 
-			result = db.Omit(clause.Associations).Save(playtest)
-		} else {
-			result = db.Omit(clause.Associations).Create(playtest)
+		return nil
+	})
+}
+
+// SaveWithCredits will upsert a playtest record along with the credit transactions it caused, so players are
+// never paid or refunded for a change that didn't stick
+func (r *PlaytestRepository) SaveWithCredits(playtest *domain.Playtest, transactions []domain.CreditTransaction) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := savePlaytest(db, playtest)
+		if err != nil {
+			return err
 		}
 
-		return result.Error
+		if len(transactions) == 0 {
+			return nil
+		}
+
+		return db.Create(&transactions).Error
 	})
 }
+
+// EncryptSecrets encrypts any TTS passwords stored as plaintext before encryption was introduced, working through
+// the table in batches. It returns how many rows were changed and is safe to run more than once.
+func (r *PlaytestRepository) EncryptSecrets() (int, error) {
+	type row struct {
+		ID          uint
+		TTSPassword string
+	}
+
+	changed := 0
+	lastID := uint(0)
+	for {
+		rows := []row{}
+		err := r.DB.Table("playtests").
+			Select("id, tts_password").
+			Where("id > ? AND tts_password <> ''", lastID).
+			Order("id ASC").
+			Limit(100).
+			Scan(&rows).Error
+		if err != nil {
+			return changed, err
+		}
+
+		if len(rows) == 0 {
+			return changed, nil
+		}
+
+		for _, rw := range rows {
+			if secret.IsEncrypted(rw.TTSPassword) {
+				continue
+			}
+
+			// Secret encrypts itself on the way into the database
+			err := r.DB.Table("playtests").Where("id = ?", rw.ID).UpdateColumn("tts_password", playtest.Secret(rw.TTSPassword)).Error
+			if err != nil {
+				return changed, err
+			}
+			changed++
+		}
+
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+func savePlaytest(db *gorm.DB, playtest *domain.Playtest) error {
+	var result *gorm.DB
+	if playtest.ID != 0 {
+		err := db.Model(playtest).Association("Players").Replace(playtest.Players)
+		if err != nil {
+			return err
+		}
+
+		// The waitlist is small and ordered, so it's simplest to rewrite it wholesale
+		err = db.Where("playtest_id = ?", playtest.ID).Delete(&domain.WaitlistEntry{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(playtest.Waitlist) > 0 {
+			err = db.Omit("User").Create(&playtest.Waitlist).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Results are rewritten wholesale too, since recording them again replaces the old ones
+		err = db.Where("playtest_id = ?", playtest.ID).Delete(&domain.SeatResult{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(playtest.Results) > 0 {
+			err = db.Omit("User").Create(&playtest.Results).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(playtest.Attendance) > 0 {
+			err = db.Omit("User").Save(&playtest.Attendance).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		result = db.Omit(clause.Associations).Save(playtest)
+	} else {
+		result = db.Omit(clause.Associations).Create(playtest)
+	}
+
+	return result.Error
+}
+
+// playtestsOfGame narrows a query to a game's playtests scheduled from up to, but not including, to.
+// Zero times leave that end of the range open and zero IDs match any event or version.
+func playtestsOfGame(gameID uint, from, to time.Time, eventID, versionID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("playtests.game_id = ?", gameID)
+
+		if !from.IsZero() {
+			db = db.Where("playtests.scheduled_date >= ?", from)
+		}
+
+		if !to.IsZero() {
+			db = db.Where("playtests.scheduled_date < ?", to)
+		}
+
+		if eventID != 0 {
+			db = db.Where("playtests.event_id = ?", eventID)
+		}
+
+		if versionID != 0 {
+			db = db.Where("playtests.game_version_id = ?", versionID)
+		}
+
+		return db
+	}
+}
+
+func orderedWaitlist(db *gorm.DB) *gorm.DB {
+	return db.Order("waitlist_entries.position ASC")
+}
+
+func orderedResults(db *gorm.DB) *gorm.DB {
+	return db.Order("seat_results.seat ASC")
+}
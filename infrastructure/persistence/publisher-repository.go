@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PublisherRepository struct {
+	DB *gorm.DB
+}
+
+// ListPublishers lists every publisher, sorted by name, optionally only those accepting submissions
+func (r *PublisherRepository) ListPublishers(accepting bool) ([]domain.Publisher, error) {
+	publishers := []domain.Publisher{}
+
+	query := r.DB.Preload("Members").Order("publishers.name ASC")
+	if accepting {
+		query = query.Where("publishers.criteria_accepting_submissions = ?", true)
+	}
+
+	result := query.Find(&publishers)
+	if result.Error != nil {
+		return []domain.Publisher{}, result.Error
+	}
+
+	return publishers, nil
+}
+
+func (r *PublisherRepository) PublisherOfID(id uint) (*domain.Publisher, error) {
+	publisher := &domain.Publisher{}
+	result := r.DB.Preload("Members").First(publisher, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return publisher, nil
+}
+
+// Save will upsert a publisher record. Members only change through SaveMembers once the publisher exists.
+func (r *PublisherRepository) Save(publisher *domain.Publisher) error {
+	if publisher.ID != 0 {
+		return r.DB.Omit(clause.Associations, "OwnerID").Save(publisher).Error
+	}
+
+	return r.DB.Omit("Members.*").Create(publisher).Error
+}
+
+// SaveMembers stores who the publisher's members are
+func (r *PublisherRepository) SaveMembers(publisher *domain.Publisher) error {
+	return r.DB.Model(publisher).Association("Members").Replace(publisher.Members)
+}
+
+// AssignPublisherOwners makes the member with the oldest account the owner of any publisher without one, such
+// as those created before publishers had owners
+func AssignPublisherOwners(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE publishers
+		SET owner_id = (
+			SELECT min(publisher_members.user_id)
+			FROM publisher_members
+			WHERE publisher_members.publisher_id = publishers.id
+		)
+		WHERE publishers.owner_id = 0
+		AND EXISTS (SELECT 1 FROM publisher_members WHERE publisher_members.publisher_id = publishers.id)`,
+	).Error
+}
@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubmissionRepository struct {
+	DB *gorm.DB
+}
+
+// SubmissionsOfPublisher lists the games submitted to a publisher, oldest first so they're reviewed in order,
+// optionally only those at a particular stage
+func (r *SubmissionRepository) SubmissionsOfPublisher(publisherID uint, stage string, limit, offset int) ([]domain.Submission, int, error) {
+	submissions := []domain.Submission{}
+
+	query := r.submissions().Where("submissions.publisher_id = ?", publisherID)
+	if stage != "" {
+		query = query.Where("submissions.stage = ?", stage)
+	}
+
+	var total int64
+	result := query.
+		Count(&total).
+		Limit(limit).
+		Offset(offset).
+		Order("submissions.id ASC").
+		Find(&submissions)
+
+	if result.Error != nil {
+		return []domain.Submission{}, 0, result.Error
+	}
+
+	return submissions, int(total), nil
+}
+
+// SubmissionsOfGame lists every submission of a game, newest first
+func (r *SubmissionRepository) SubmissionsOfGame(gameID uint) ([]domain.Submission, error) {
+	submissions := []domain.Submission{}
+
+	result := r.submissions().
+		Where("submissions.game_id = ?", gameID).
+		Order("submissions.id DESC").
+		Find(&submissions)
+
+	if result.Error != nil {
+		return []domain.Submission{}, result.Error
+	}
+
+	return submissions, nil
+}
+
+func (r *SubmissionRepository) SubmissionOfID(id uint) (*domain.Submission, error) {
+	submission := &domain.Submission{}
+	result := r.submissions().Preload("Notes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("submission_notes.id ASC")
+	}).Preload("Notes.Author").First(submission, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return submission, nil
+}
+
+// Save will upsert a submission record. Notes are saved on their own.
+func (r *SubmissionRepository) Save(submission *domain.Submission) error {
+	if submission.ID != 0 {
+		return r.DB.Omit(clause.Associations).Save(submission).Error
+	}
+
+	return r.DB.Omit(clause.Associations).Create(submission).Error
+}
+
+func (r *SubmissionRepository) SaveNote(note *domain.SubmissionNote) error {
+	return r.DB.Omit(clause.Associations).Create(note).Error
+}
+
+// submissions preloads everything both sides need to see about a submission
+func (r *SubmissionRepository) submissions() *gorm.DB {
+	return r.DB.Model(&domain.Submission{}).
+		Preload("Game").
+		Preload("Game.Designers").
+		Preload("Publisher").
+		Preload("Publisher.Members").
+		Preload("SubmittedBy").
+		Preload("SellSheet")
+}
+
+// SaveReview will update a submission as it moves through review. Accepting a submission signs its game, in
+// which case the game's status change is saved along with it.
+func (r *SubmissionRepository) SaveReview(submission *domain.Submission, signed *domain.StatusChange) error {
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := db.Omit(clause.Associations).Save(submission).Error
+		if err != nil {
+			return err
+		}
+
+		if signed == nil {
+			return nil
+		}
+
+		return saveStatusChange(db, &submission.Game, signed)
+	})
+}
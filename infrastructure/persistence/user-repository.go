@@ -116,12 +116,17 @@ func (r *UserRepository) ListUsers(name string, limit, offset int, sort string)
 
 // Save will upsert a user record
 func (r *UserRepository) Save(user *domain.User) error {
-	var result *gorm.DB
-	if user.ID != 0 {
-		result = r.DB.Save(user)
-	} else {
-		result = r.DB.Create(user)
+	if user.ID == 0 {
+		return r.DB.Create(user).Error
 	}
 
-	return result.Error
+	return r.DB.Transaction(func(db *gorm.DB) error {
+		err := db.Save(user).Error
+		if err != nil {
+			return err
+		}
+
+		// Designer names are searchable
+		return indexGames(db, "games.id IN (SELECT game_id FROM game_designers WHERE user_id = ?)", user.ID)
+	})
 }
@@ -0,0 +1,69 @@
+package rulebook
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+)
+
+var htmlDocument = template.Must(template.New("rulebook").Funcs(template.FuncMap{
+	"anchor":  anchor,
+	"stats":   statsLines,
+	"content": func(s string) template.HTML { return template.HTML(s) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: Georgia, serif; line-height: 1.5; max-width: 45em; margin: 0 auto; padding: 2em; }
+.title-page, .contents { page-break-after: always; }
+.title-page { text-align: center; padding-top: 30vh; }
+.title-page ul { list-style: none; padding: 0; }
+.contents a { text-decoration: none; }
+section.rules { page-break-before: always; }
+@media print { .title-page, .contents { min-height: 100vh; } }
+</style>
+</head>
+<body>
+<header class="title-page">
+<h1>{{ .Title }}</h1>
+{{- if .Designers }}
+<p class="designers"><em>By {{ range $i, $d := .Designers }}{{ if $i }}, {{ end }}{{ $d }}{{ end }}</em></p>
+{{- end }}
+{{- if .Overview }}
+<p class="overview">{{ .Overview }}</p>
+{{- end }}
+<ul class="stats">
+{{- range stats .Stats }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+</header>
+<nav class="contents">
+<h2>Contents</h2>
+<ol>
+{{- range $i, $title := .Contents }}
+<li><a href="#{{ anchor $i }}">{{ $title }}</a></li>
+{{- end }}
+</ol>
+</nav>
+{{- range $i, $section := .Sections }}
+<section class="rules" id="{{ anchor $i }}">
+<h2>{{ $section.Title }}</h2>
+{{ content $section.Content }}
+</section>
+{{- end }}
+</body>
+</html>
+`))
+
+func renderHTML(book domain.Rulebook) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlDocument.Execute(&buf, book); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,132 @@
+package rulebook
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// Raw HTML is left out of the converted content, and links with dangerous schemes (javascript: and the
+// like) are dropped, since goldmark's renderer is safe by default
+var markdown = goldmark.New(goldmark.WithExtensions(extension.Table, extension.Strikethrough))
+
+// EmptyDocument returned when an imported document has nothing in it to turn into rules
+type EmptyDocument struct{}
+
+func (e EmptyDocument) Error() string {
+	return "document has no rules to import"
+}
+
+// ParseMarkdown splits a Markdown document into rules sections by heading, converting each body to HTML.
+// Sections are split on the top heading level used. If that level is only used once, at the top of the
+// document, it's taken as the document's title and sections are split on the next level down instead.
+// Anything before the first heading becomes an introduction.
+func ParseMarkdown(document []byte) ([]game.RulesSection, error) {
+	doc := markdown.Parser().Parse(text.NewReader(document))
+
+	blocks := []ast.Node{}
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		blocks = append(blocks, n)
+	}
+
+	level := splitLevel(blocks)
+	if level > 0 && headingLevel(blocks[0]) == level && countHeadings(blocks, level) == 1 {
+		if next := splitLevel(blocks[1:]); next > 0 {
+			blocks, level = blocks[1:], next
+		}
+	}
+
+	sections := []game.RulesSection{}
+	title, body := "", []ast.Node{}
+	flush := func() error {
+		content, err := render(document, body)
+		if err != nil {
+			return err
+		}
+
+		// Text before the first heading only becomes a section if there is some
+		if title == "" && content == "" {
+			return nil
+		}
+
+		if title == "" {
+			title = "Introduction"
+		}
+
+		sections = append(sections, game.RulesSection{Title: title, Content: content})
+
+		return nil
+	}
+
+	for _, n := range blocks {
+		if level == 0 || headingLevel(n) != level {
+			body = append(body, n)
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return nil, err
+		}
+
+		title, body = strings.TrimSpace(string(n.Text(document))), []ast.Node{}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(sections) == 0 {
+		return nil, EmptyDocument{}
+	}
+
+	return sections, nil
+}
+
+// render converts a run of Markdown blocks to HTML
+func render(document []byte, blocks []ast.Node) (string, error) {
+	var buf bytes.Buffer
+	for _, n := range blocks {
+		if err := markdown.Renderer().Render(&buf, document, n); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// splitLevel is the top heading level used, or 0 when there are no headings
+func splitLevel(blocks []ast.Node) int {
+	level := 0
+	for _, n := range blocks {
+		if l := headingLevel(n); l > 0 && (level == 0 || l < level) {
+			level = l
+		}
+	}
+
+	return level
+}
+
+func countHeadings(blocks []ast.Node, level int) int {
+	count := 0
+	for _, n := range blocks {
+		if headingLevel(n) == level {
+			count++
+		}
+	}
+
+	return count
+}
+
+// headingLevel of a block, or 0 when it isn't a heading
+func headingLevel(n ast.Node) int {
+	if heading, ok := n.(*ast.Heading); ok {
+		return heading.Level
+	}
+
+	return 0
+}
@@ -0,0 +1,82 @@
+package rulebook
+
+import "testing"
+
+func TestParseMarkdown(t *testing.T) {
+	var tests = []struct {
+		name     string
+		document string
+		titles   []string
+		contents []string
+	}{
+		{
+			"sections split on the top heading level",
+			"# Setup\n\nShuffle.\n\n## Dealing\n\nDeal 5.\n\n# Scoring\n\nMost points wins.",
+			[]string{"Setup", "Scoring"},
+			[]string{"<p>Shuffle.</p>\n<h2>Dealing</h2>\n<p>Deal 5.</p>", "<p>Most points wins.</p>"},
+		},
+		{
+			"a lone top heading is taken as the title",
+			"# Snail Race\n\n## Setup\n\nShuffle.\n\n## Scoring\n\nMost points wins.",
+			[]string{"Setup", "Scoring"},
+			[]string{"<p>Shuffle.</p>", "<p>Most points wins.</p>"},
+		},
+		{
+			"a lone top heading with nothing below it is kept",
+			"# Setup\n\nShuffle.",
+			[]string{"Setup"},
+			[]string{"<p>Shuffle.</p>"},
+		},
+		{
+			"text before the first heading becomes an introduction",
+			"Snails race.\n\n## Setup\n\nShuffle.",
+			[]string{"Introduction", "Setup"},
+			[]string{"<p>Snails race.</p>", "<p>Shuffle.</p>"},
+		},
+		{
+			"text under the title becomes an introduction",
+			"# Snail Race\n\nSnails race.\n\n## Setup\n\nShuffle.",
+			[]string{"Introduction", "Setup"},
+			[]string{"<p>Snails race.</p>", "<p>Shuffle.</p>"},
+		},
+		{
+			"documents without headings are a single introduction",
+			"Just race.",
+			[]string{"Introduction"},
+			[]string{"<p>Just race.</p>"},
+		},
+		{
+			"raw HTML is left out",
+			"## Setup\n\n<script>alert(1)</script>\n\nShuffle.",
+			[]string{"Setup"},
+			[]string{"<!-- raw HTML omitted -->\n<p>Shuffle.</p>"},
+		},
+	}
+
+	for _, tt := range tests {
+		sections, err := ParseMarkdown([]byte(tt.document))
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+
+		if len(sections) != len(tt.titles) {
+			t.Errorf("%s: expected %d sections, got %+v", tt.name, len(tt.titles), sections)
+			continue
+		}
+
+		for i, section := range sections {
+			if section.Title != tt.titles[i] || section.Content != tt.contents[i] {
+				t.Errorf("%s: section %d expected %q %q, got %q %q", tt.name, i, tt.titles[i], tt.contents[i], section.Title, section.Content)
+			}
+		}
+	}
+}
+
+func TestParseMarkdownEmpty(t *testing.T) {
+	for _, document := range []string{"", "  \n\n"} {
+		if _, err := ParseMarkdown([]byte(document)); err != (EmptyDocument{}) {
+			t.Errorf("Expected %q to be rejected as empty, got %v", document, err)
+		}
+	}
+}
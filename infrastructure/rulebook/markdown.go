@@ -0,0 +1,238 @@
+package rulebook
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLines      = regexp.MustCompile(`\n{3,}`)
+	markdownSpecial = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+)
+
+func renderMarkdown(book domain.Rulebook) []byte {
+	var b strings.Builder
+
+	// Title page. Titles, names and the overview are plain text, so anything Markdown would pick up is escaped.
+	fmt.Fprintf(&b, "# %s\n\n", escapeMarkdown(book.Title))
+	if len(book.Designers) > 0 {
+		designers := make([]string, len(book.Designers))
+		for i, d := range book.Designers {
+			designers[i] = escapeMarkdown(d)
+		}
+
+		fmt.Fprintf(&b, "_By %s_\n\n", strings.Join(designers, ", "))
+	}
+
+	if book.Overview != "" {
+		fmt.Fprintf(&b, "%s\n\n", escapeMarkdown(book.Overview))
+	}
+
+	for _, line := range statsLines(book.Stats) {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+
+	// Table of contents
+	b.WriteString("\n## Contents\n\n")
+	for i, title := range book.Contents() {
+		fmt.Fprintf(&b, "%d. [%s](#%s)\n", i+1, escapeMarkdown(title), anchor(i))
+	}
+
+	// Sections
+	for i, section := range book.Sections {
+		fmt.Fprintf(&b, "\n<a id=\"%s\"></a>\n\n## %s\n\n", anchor(i), escapeMarkdown(section.Title))
+		b.WriteString(htmlToMarkdown(section.Content))
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String())
+}
+
+// escapeMarkdown keeps plain text from being read as Markdown formatting, including a heading, quote or
+// list marker at the start of the line
+func escapeMarkdown(text string) string {
+	escaped := markdownSpecial.Replace(collapse(strings.TrimSpace(text)))
+	if escaped != "" && strings.ContainsAny(escaped[:1], "#>-+") {
+		escaped = `\` + escaped
+	}
+
+	return escaped
+}
+
+// htmlToMarkdown converts a section's content. Headings are nested below the section's own heading.
+func htmlToMarkdown(content string) string {
+	c := &markdownConverter{}
+
+	var b strings.Builder
+	for _, n := range parseFragment(content) {
+		b.WriteString(c.convert(n))
+	}
+
+	return strings.TrimSpace(blankLines.ReplaceAllString(b.String(), "\n\n"))
+}
+
+// markdownConverter keeps track of how deeply lists are nested while converting
+type markdownConverter struct {
+	depth int
+}
+
+func (c *markdownConverter) children(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.convert(child))
+	}
+
+	return b.String()
+}
+
+func (c *markdownConverter) convert(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return markdownSpecial.Replace(collapse(n.Data))
+	}
+
+	if n.Type != html.ElementNode {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1]-'0') + 2
+		if level > 6 {
+			level = 6
+		}
+
+		return "\n\n" + strings.Repeat("#", level) + " " + strings.TrimSpace(c.children(n)) + "\n\n"
+	case atom.P, atom.Div, atom.Section:
+		return "\n\n" + strings.TrimSpace(c.children(n)) + "\n\n"
+	case atom.Br:
+		return "  \n"
+	case atom.Hr:
+		return "\n\n---\n\n"
+	case atom.Strong, atom.B:
+		return wrapInline("**", c.children(n))
+	case atom.Em, atom.I:
+		return wrapInline("_", c.children(n))
+	case atom.Code:
+		return "`" + textOf(n) + "`"
+	case atom.Pre:
+		return "\n\n```\n" + strings.Trim(textOf(n), "\n") + "\n```\n\n"
+	case atom.A:
+		return fmt.Sprintf("[%s](%s)", strings.TrimSpace(c.children(n)), attr(n, "href"))
+	case atom.Img:
+		return fmt.Sprintf("![%s](%s)", attr(n, "alt"), attr(n, "src"))
+	case atom.Blockquote:
+		quoted := strings.Split(strings.TrimSpace(c.children(n)), "\n")
+		for i, line := range quoted {
+			quoted[i] = "> " + line
+		}
+
+		return "\n\n" + strings.Join(quoted, "\n") + "\n\n"
+	case atom.Ul, atom.Ol:
+		return c.list(n)
+	case atom.Table:
+		return c.table(n)
+	default:
+		return c.children(n)
+	}
+}
+
+// list converts a list, indenting nested lists under their items
+func (c *markdownConverter) list(n *html.Node) string {
+	indent := strings.Repeat("  ", c.depth)
+	c.depth++
+	defer func() { c.depth-- }()
+
+	var b strings.Builder
+	number := 1
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+
+		text := strings.TrimSpace(blankLines.ReplaceAllString(c.children(item), "\n\n"))
+		text = strings.ReplaceAll(text, "\n\n", "\n")
+		fmt.Fprintf(&b, "%s%s%s\n", indent, marker, text)
+	}
+
+	if c.depth > 1 {
+		return "\n" + strings.TrimRight(b.String(), "\n")
+	}
+
+	return "\n\n" + b.String() + "\n"
+}
+
+// table converts a table, treating its first row as the header
+func (c *markdownConverter) table(n *html.Node) string {
+	rows := [][]string{}
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.DataAtom != atom.Tr {
+				walk(child)
+				continue
+			}
+
+			row := []string{}
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.DataAtom == atom.Td || cell.DataAtom == atom.Th {
+					row = append(row, strings.TrimSpace(strings.ReplaceAll(c.children(cell), "|", `\|`)))
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	walk(n)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n")
+	for i, row := range rows {
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(row, " | "))
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", len(row)) + "\n")
+		}
+	}
+
+	return b.String() + "\n"
+}
+
+// wrapInline adds emphasis markers around text, keeping any surrounding spaces outside the markers
+func wrapInline(marker, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	leading := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	trailing := text[len(strings.TrimRight(text, " ")):]
+
+	return leading + marker + trimmed + marker + trailing
+}
+
+// textOf gathers all the raw text below a node, without collapsing whitespace
+func textOf(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textOf(child))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,323 @@
+package rulebook
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	pdfFont       = "Helvetica"
+	pdfFontSize   = 11
+	pdfLineHeight = 6
+	pdfIndent     = 6
+)
+
+func renderPDF(book domain.Rulebook) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "Letter", "")
+	pdf.SetTitle(book.Title, true)
+	pdf.SetAuthor(strings.Join(book.Designers, ", "), true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+
+	// Core fonts only cover cp1252, so translate everything we write
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		if pdf.PageNo() == 1 {
+			return
+		}
+
+		pdf.SetY(-15)
+		pdf.SetFont(pdfFont, "I", 9)
+		pdf.CellFormat(0, 10, strconv.Itoa(pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	// Title page
+	pdf.AddPage()
+	pdf.SetY(80)
+	pdf.SetFont(pdfFont, "B", 28)
+	pdf.MultiCell(0, 12, tr(book.Title), "", "C", false)
+	if len(book.Designers) > 0 {
+		pdf.SetFont(pdfFont, "I", 14)
+		pdf.MultiCell(0, 10, tr("By "+strings.Join(book.Designers, ", ")), "", "C", false)
+	}
+
+	pdf.Ln(8)
+	if book.Overview != "" {
+		pdf.SetFont(pdfFont, "", 12)
+		pdf.MultiCell(0, 7, tr(book.Overview), "", "C", false)
+		pdf.Ln(8)
+	}
+
+	pdf.SetFont(pdfFont, "", 12)
+	for _, line := range statsLines(book.Stats) {
+		pdf.CellFormat(0, 7, tr(line), "", 1, "C", false, 0, "")
+	}
+
+	// Table of contents. Page numbers aren't known until each section is written, so they're aliases
+	// filled in when the document is output.
+	pdf.AddPage()
+	pdf.SetFont(pdfFont, "B", 20)
+	pdf.CellFormat(0, 12, "Contents", "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont(pdfFont, "", 12)
+	alias := aliasPrefix(book)
+	links := make([]int, len(book.Sections))
+	for i, title := range book.Contents() {
+		links[i] = pdf.AddLink()
+		pdf.CellFormat(150, 8, tr(fmt.Sprintf("%d. %s", i+1, title)), "", 0, "L", false, links[i], "")
+		pdf.CellFormat(0, 8, pageAlias(alias, i), "", 1, "R", false, links[i], "")
+	}
+
+	// Sections
+	w := &pdfWriter{pdf: pdf, tr: tr}
+	for i, section := range book.Sections {
+		pdf.AddPage()
+		pdf.SetLink(links[i], 0, -1)
+		pdf.RegisterAlias(pageAlias(alias, i), strconv.Itoa(pdf.PageNo()))
+
+		pdf.SetFont(pdfFont, "B", 18)
+		pdf.MultiCell(0, 10, tr(section.Title), "", "L", false)
+		pdf.Ln(2)
+
+		w.reset()
+		for _, n := range parseFragment(section.Content) {
+			w.write(n)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// aliasPrefix picks a prefix for the page number aliases that appears nowhere in the rulebook's text, since
+// aliases are swapped out everywhere in the document, not just in the table of contents
+func aliasPrefix(book domain.Rulebook) string {
+	text := strings.Join(append([]string{book.Title, book.Overview}, book.Designers...), "\n")
+	for _, section := range book.Sections {
+		text += "\n" + section.Title + "\n" + html.UnescapeString(section.Content)
+	}
+
+	prefix := "{page"
+	for strings.Contains(text, prefix) {
+		prefix += "~"
+	}
+
+	return prefix
+}
+
+func pageAlias(prefix string, i int) string {
+	return fmt.Sprintf("%s%d}", prefix, i+1)
+}
+
+// pdfWriter flows a section's HTML into the document, tracking the current text style as it goes
+type pdfWriter struct {
+	pdf    *gofpdf.Fpdf
+	tr     func(string) string
+	bold   int
+	italic int
+	mono   int
+	size   float64
+}
+
+func (w *pdfWriter) reset() {
+	w.bold, w.italic, w.mono = 0, 0, 0
+	w.size = pdfFontSize
+	w.font()
+}
+
+// font applies the current style
+func (w *pdfWriter) font() {
+	style := ""
+	if w.bold > 0 {
+		style += "B"
+	}
+
+	if w.italic > 0 {
+		style += "I"
+	}
+
+	family := pdfFont
+	if w.mono > 0 {
+		family = "Courier"
+	}
+
+	w.pdf.SetFont(family, style, w.size)
+}
+
+// atLineStart is true when nothing has been written on the current line
+func (w *pdfWriter) atLineStart() bool {
+	left, _, _, _ := w.pdf.GetMargins()
+	return w.pdf.GetX() <= left+0.01
+}
+
+// newline ends the current line, if anything has been written on it
+func (w *pdfWriter) newline() {
+	if !w.atLineStart() {
+		w.pdf.Ln(pdfLineHeight)
+	}
+}
+
+// block separates a block element from whatever came before it
+func (w *pdfWriter) block() {
+	w.newline()
+	w.pdf.Ln(2)
+}
+
+func (w *pdfWriter) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		w.write(child)
+	}
+}
+
+func (w *pdfWriter) styled(n *html.Node, counter *int) {
+	*counter++
+	w.font()
+	w.children(n)
+	*counter--
+	w.font()
+}
+
+func (w *pdfWriter) write(n *html.Node) {
+	if n.Type == html.TextNode {
+		text := collapse(n.Data)
+		if w.atLineStart() {
+			text = strings.TrimLeft(text, " ")
+		}
+
+		if text != "" {
+			w.pdf.Write(pdfLineHeight, w.tr(text))
+		}
+
+		return
+	}
+
+	if n.Type != html.ElementNode {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		sizes := map[atom.Atom]float64{atom.H1: 16, atom.H2: 14, atom.H3: 13}
+		size, ok := sizes[n.DataAtom]
+		if !ok {
+			size = 12
+		}
+
+		w.block()
+		w.size = size
+		w.styled(n, &w.bold)
+		w.size = pdfFontSize
+		w.font()
+		w.newline()
+	case atom.P, atom.Div, atom.Section, atom.Blockquote:
+		w.block()
+		w.children(n)
+		w.newline()
+	case atom.Br:
+		w.pdf.Ln(pdfLineHeight)
+	case atom.Hr:
+		w.block()
+		left, _, right, _ := w.pdf.GetMargins()
+		width, _ := w.pdf.GetPageSize()
+		w.pdf.Line(left, w.pdf.GetY(), width-right, w.pdf.GetY())
+		w.pdf.Ln(2)
+	case atom.Strong, atom.B, atom.Th:
+		w.styled(n, &w.bold)
+	case atom.Em, atom.I:
+		w.styled(n, &w.italic)
+	case atom.Code:
+		w.styled(n, &w.mono)
+	case atom.Pre:
+		w.block()
+		w.mono++
+		w.font()
+		for _, line := range strings.Split(strings.Trim(textOf(n), "\n"), "\n") {
+			w.pdf.Write(pdfLineHeight, w.tr(line))
+			w.pdf.Ln(pdfLineHeight)
+		}
+		w.mono--
+		w.font()
+	case atom.A:
+		href := attr(n, "href")
+		if href == "" || n.FirstChild == nil || n.FirstChild != n.LastChild || n.FirstChild.Type != html.TextNode {
+			w.children(n)
+			return
+		}
+
+		w.pdf.SetTextColor(0, 0, 238)
+		w.pdf.WriteLinkString(pdfLineHeight, w.tr(collapse(n.FirstChild.Data)), href)
+		w.pdf.SetTextColor(0, 0, 0)
+	case atom.Img:
+		if alt := attr(n, "alt"); alt != "" {
+			w.italic++
+			w.font()
+			w.pdf.Write(pdfLineHeight, w.tr("["+alt+"]"))
+			w.italic--
+			w.font()
+		}
+	case atom.Ul, atom.Ol:
+		w.list(n)
+	case atom.Tr:
+		w.newline()
+		first := true
+		for cell := n.FirstChild; cell != nil; cell = cell.NextSibling {
+			if cell.Type != html.ElementNode {
+				continue
+			}
+
+			if !first {
+				w.pdf.Write(pdfLineHeight, "  |  ")
+			}
+			first = false
+			w.write(cell)
+		}
+		w.newline()
+	case atom.Table:
+		w.block()
+		w.children(n)
+	default:
+		w.children(n)
+	}
+}
+
+// list writes each item on its own line behind a bullet or number. Wrapped lines and nested lists are
+// indented under the item's text.
+func (w *pdfWriter) list(n *html.Node) {
+	w.newline()
+
+	left, top, right, _ := w.pdf.GetMargins()
+	number := 1
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := w.tr("•")
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d.", number)
+			number++
+		}
+
+		w.newline()
+		w.pdf.SetX(left)
+		w.pdf.CellFormat(pdfIndent, pdfLineHeight, marker, "", 0, "L", false, 0, "")
+		w.pdf.SetMargins(left+pdfIndent, top, right)
+		w.children(item)
+		w.newline()
+		w.pdf.SetMargins(left, top, right)
+		w.pdf.SetX(left)
+	}
+}
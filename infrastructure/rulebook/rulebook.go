@@ -0,0 +1,130 @@
+// Package rulebook renders a game's rules as a single printable document, in Markdown, HTML or PDF.
+// Sections are stored as HTML fragments, so every format starts by parsing them.
+package rulebook
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Format of an exported rulebook
+type Format string
+
+const (
+	// Markdown documents are plain text, handy for pasting into a wiki or forum post
+	Markdown Format = "md"
+
+	// HTML documents are standalone pages, styled for printing from a browser
+	HTML = "html"
+
+	// PDF documents are ready to print as is
+	PDF = "pdf"
+)
+
+// ContentType for serving documents in this format
+func (f Format) ContentType() string {
+	switch f {
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	case HTML:
+		return "text/html; charset=utf-8"
+	case PDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Filename suggests a name for the downloaded document, e.g. "my-game-rules.pdf"
+func Filename(title string, format Format) string {
+	slug := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if slug == "" {
+		return fmt.Sprintf("rules.%s", format)
+	}
+
+	return fmt.Sprintf("%s-rules.%s", slug, format)
+}
+
+// UnsupportedFormat returned when asked for a format we can't render
+type UnsupportedFormat struct {
+	PassedValue string
+}
+
+func (e UnsupportedFormat) Error() string {
+	return fmt.Sprintf("unsupported format '%s'", e.PassedValue)
+}
+
+// Render produces the rulebook in the requested format
+func Render(book domain.Rulebook, format Format) ([]byte, error) {
+	switch format {
+	case Markdown:
+		return renderMarkdown(book), nil
+	case HTML:
+		return renderHTML(book)
+	case PDF:
+		return renderPDF(book)
+	default:
+		return nil, UnsupportedFormat{PassedValue: string(format)}
+	}
+}
+
+// anchor links each section to its entry in the table of contents
+func anchor(i int) string {
+	return fmt.Sprintf("section-%d", i+1)
+}
+
+// statsLines describes the game for the title page
+func statsLines(stats game.Stats) []string {
+	players := fmt.Sprintf("Players: %d-%d", stats.MinPlayers, stats.MaxPlayers)
+	if stats.MinPlayers == stats.MaxPlayers {
+		players = fmt.Sprintf("Players: %d", stats.MinPlayers)
+	}
+
+	return []string{
+		players,
+		fmt.Sprintf("Ages: %d+", stats.MinAge),
+		fmt.Sprintf("Playtime: %d minutes", stats.EstimatedPlaytime),
+	}
+}
+
+// parseFragment breaks a section's HTML content into nodes. Content that isn't valid HTML is still
+// parsed as leniently as a browser would.
+func parseFragment(content string) []*html.Node {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return []*html.Node{{Type: html.TextNode, Data: content}}
+	}
+
+	return nodes
+}
+
+// attr returns the value of a node's attribute, or blank if it isn't set
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+var (
+	whitespace      = regexp.MustCompile(`\s+`)
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// collapse squeezes runs of whitespace into a single space, the way browsers display text
+func collapse(text string) string {
+	return whitespace.ReplaceAllString(text, " ")
+}
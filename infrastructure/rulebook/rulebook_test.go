@@ -0,0 +1,83 @@
+package rulebook
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	var tests = []struct {
+		content  string
+		expected string
+	}{
+		{"<h1>Setup</h1><p>Deal <strong>five</strong> cards.</p>", "### Setup\n\nDeal **five** cards."},
+		{"<h5>Deep</h5>", "###### Deep"},
+		{"<ul><li>Draw</li><li>Play</li></ul>", "- Draw\n- Play"},
+		{"<ol><li>Draw</li><li>Play<ul><li>Once</li></ul></li></ol>", "1. Draw\n2. Play\n  - Once"},
+		{
+			"<table><thead><tr><th>Card</th><th>Cost</th></tr></thead><tbody><tr><td>Snail</td><td>1 | 2</td></tr></tbody></table>",
+			"| Card | Cost |\n| --- | --- |\n| Snail | 1 \\| 2 |",
+		},
+		{"<p>2*3 &lt;b&gt; [x]</p>", "2\\*3 \\<b> \\[x\\]"},
+	}
+
+	for _, tt := range tests {
+		actual := htmlToMarkdown(tt.content)
+		if actual != tt.expected {
+			t.Errorf("Converting %q: expected %q, got %q", tt.content, tt.expected, actual)
+		}
+	}
+}
+
+func TestRenderMarkdownEscapesPlainText(t *testing.T) {
+	book := domain.Rulebook{
+		Title:     "# Snails *Race*",
+		Designers: []string{"_Ann_", "[Bob]"},
+		Sections:  []game.RulesSection{{Title: "Set<up>", Content: "<p>Go</p>"}},
+	}
+
+	md := string(renderMarkdown(book))
+	for _, expected := range []string{"# \\# Snails \\*Race\\*\n", "_By \\_Ann\\_, \\[Bob\\]_", "1. [Set\\<up>](#section-1)", "## Set\\<up>\n"} {
+		if !strings.Contains(md, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, md)
+		}
+	}
+}
+
+func TestAliasPrefix(t *testing.T) {
+	book := domain.Rulebook{
+		Title:    "{page",
+		Sections: []game.RulesSection{{Title: "Scoring", Content: "<p>&#123;page~1}</p>"}},
+	}
+
+	prefix := aliasPrefix(book)
+	if prefix != "{page~~" {
+		t.Errorf("Expected the prefix to avoid the rulebook's text, got %q", prefix)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	book := domain.Rulebook{
+		Title:     "Snail Race",
+		Overview:  "Snails race to the finish – slowly.",
+		Stats:     game.Stats{MinPlayers: 2, MaxPlayers: 4, MinAge: 8, EstimatedPlaytime: 30},
+		Designers: []string{"Ann"},
+		Sections: []game.RulesSection{
+			{Title: "Setup", Content: "<p>Deal <em>five</em> cards. {page1}</p><ul><li>Draw</li></ul>"},
+			{Title: "Scoring", Content: "<table><tr><th>Place</th><th>Points</th></tr><tr><td>1st</td><td>5</td></tr></table><pre>a\nb</pre>"},
+		},
+	}
+
+	pdf, err := Render(book, PDF)
+	if err != nil {
+		t.Fatalf("Expected the PDF to render, got %v", err)
+	}
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.Contains(pdf, []byte("%%EOF")) {
+		t.Error("Expected a complete PDF document")
+	}
+}
@@ -0,0 +1,98 @@
+// Package secret encrypts sensitive values before they're stored in the database, using AES-GCM.
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+)
+
+// prefix marks values that have been encrypted. Anything without it was stored before encryption
+// was introduced and is passed through as is.
+const prefix = "enc:"
+
+var (
+	// Instance is the cipher used to encrypt values at rest. It must be set up before the database is used.
+	Instance *Cipher
+
+	// ErrNoKey returned when encrypting or decrypting before a key has been configured
+	ErrNoKey = errors.New("no encryption key configured")
+
+	// ErrMalformed returned when an encrypted value can't be decoded
+	ErrMalformed = errors.New("malformed encrypted value")
+)
+
+// Cipher encrypts and decrypts strings with a single key
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher derives an AES-256 key from the provided passphrase
+func NewCipher(passphrase string) (*Cipher, error) {
+	if passphrase == "" {
+		return nil, ErrNoKey
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// IsEncrypted checks if the value was created by Encrypt, rather than stored as plaintext
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt seals the plaintext with a random nonce and returns it base64 encoded
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if c == nil {
+		return "", ErrNoKey
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value created by Encrypt. Values that were never encrypted are returned unchanged.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	if c == nil {
+		return "", ErrNoKey
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, prefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
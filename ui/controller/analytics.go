@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController handles /games/:id/analytics routes
+type AnalyticsController struct {
+	AnalyticsService *app.AnalyticsService
+}
+
+// GameAnalytics returns balance analytics computed from a game's recorded playtest outcomes
+// @Summary Return balance analytics computed from a game's recorded playtest outcomes
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param query query app.GameAnalyticsRequest false "Filters for playtests"
+// @Success 200 {object} app.GameAnalyticsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/analytics [get]
+func (t *AnalyticsController) GameAnalytics(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.GameAnalyticsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	analytics, err := t.AnalyticsService.GameAnalytics(uint(gameID), &req)
+	if err != nil {
+		var perr *time.ParseError
+		if errors.As(err, &perr) {
+			requestErrorResponse(c, err.Error())
+			return
+		}
+
+		if errors.As(err, &domain.EventNotFound{}) || errors.As(err, &domain.GameNotFound{}) {
+			notFoundResponse(c, err.Error())
+			return
+		}
+
+		serverErrorResponse(c, "failed to compute analytics")
+		return
+	}
+
+	c.JSON(200, app.GameAnalyticsResponse{Analytics: *analytics})
+}
@@ -82,6 +82,41 @@ func (t *AuthController) UpdateUser(c *gin.Context) {
 	c.JSON(200, app.UserResponse{User: user})
 }
 
+// CreditHistory retrieves the authenticated user's credit balance and transactions
+// @Summary Retrieve the authenticated user's credit balance and transactions
+// @Produce json
+// @Param query query app.CreditHistoryRequest false "Pagination"
+// @Success 200 {object} app.CreditHistoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags auth
+// @Router /auth/user/credits [get]
+func (t *AuthController) CreditHistory(c *gin.Context) {
+	userID := userID(c)
+
+	// Validate request
+	var req app.CreditHistoryRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	balance, transactions, total, err := t.AuthService.CreditHistory(&req, userID)
+	if err != nil {
+		serverErrorResponse(c, "failed to fetch credits")
+		return
+	}
+
+	c.JSON(200, app.CreditHistoryResponse{
+		Balance:      balance,
+		Transactions: transactions,
+		Total:        total,
+		Limit:        req.Limit,
+		Offset:       req.Offset,
+	})
+}
+
 // RequestResetPassword sends a password reset email to the specified email
 // @Summary Send a password reset email to the specified email
 // @Accept json
@@ -36,6 +36,15 @@ func requestErrorResponse(c *gin.Context, err string) {
 	c.AbortWithStatusJSON(400, RequestErrorResponse{Error: err})
 }
 
+// ConflictResponse to be paired with a 409
+type ConflictResponse struct {
+	Error string `json:"error"`
+}
+
+func conflictResponse(c *gin.Context, err string) {
+	c.AbortWithStatusJSON(409, ConflictResponse{Error: err})
+}
+
 // ValidationErrorResponse for invalid requests
 type ValidationErrorResponse struct {
 	Errors map[string]string `json:"errors"`
@@ -70,6 +79,10 @@ func unauthorizedResponse(c *gin.Context) {
 	c.AbortWithStatusJSON(401, UnauthorizedResponse{Error: "unauthorized"})
 }
 
+func forbiddenResponse(c *gin.Context, err string) {
+	c.AbortWithStatusJSON(403, UnauthorizedResponse{Error: err})
+}
+
 // userID helper function to extract the user's ID from the session
 func userID(c *gin.Context) uint {
 	// Retrieve the user ID from the session
@@ -82,3 +95,14 @@ func userID(c *gin.Context) uint {
 
 	return id.(uint)
 }
+
+// optionalUserID helper function to extract the user's ID from the session, if they're logged in. Anonymous users get 0.
+func optionalUserID(c *gin.Context) uint {
+	session := sessions.Default(c)
+	id := session.Get("user_id")
+	if id == nil {
+		return 0
+	}
+
+	return id.(uint)
+}
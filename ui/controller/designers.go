@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/gin-gonic/gin"
+)
+
+// DesignerController handles the routes for who designs each game, and /invitations
+type DesignerController struct {
+	DesignerService *app.DesignerService
+}
+
+// ListInvitations lists every invitation to co-design a game, newest first. Designers only.
+// @Summary List every invitation to co-design a game, newest first. Designers only.
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Success 200 {object} app.ListInvitationsResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/invitations [get]
+func (t *DesignerController) ListInvitations(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	invitations, err := t.DesignerService.ListInvitations(uint(gameID), userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to fetch invitations")
+		return
+	}
+
+	c.JSON(200, app.ListInvitationsResponse{Invitations: invitations})
+}
+
+// InviteDesigner invites a user, or someone by email, to co-design a game. Designers only.
+// @Summary Invite a user, or someone by email, to co-design a game. Designers only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param invitation body app.InviteDesignerRequest true "Who to invite"
+// @Success 201 {object} app.InvitationResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/invitations [post]
+func (t *DesignerController) InviteDesigner(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.InviteDesignerRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	invitation, err := t.DesignerService.InviteDesigner(uint(gameID), &req, userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to invite designer")
+		return
+	}
+
+	c.JSON(201, app.InvitationResponse{Invitation: invitation})
+}
+
+// RemoveDesigner takes a designer off a game. Designers may leave on their own, while only the owner may
+// remove others.
+// @Summary Take a designer off a game. Designers may leave on their own, while only the owner may remove others.
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param user path integer true "Designer's user ID"
+// @Success 200 {object} app.GameResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/designers/:user [delete]
+func (t *DesignerController) RemoveDesigner(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	designerID, err := strconv.ParseUint(c.Param("user"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	g, err := t.DesignerService.RemoveDesigner(uint(gameID), uint(designerID), userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to remove designer")
+		return
+	}
+
+	c.JSON(200, app.GameResponse{Game: g})
+}
+
+// TransferOwnership hands a game to another of its designers. Owner only.
+// @Summary Hand a game to another of its designers. Owner only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param owner body app.TransferOwnershipRequest true "New owner"
+// @Success 200 {object} app.GameResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/owner [put]
+func (t *DesignerController) TransferOwnership(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.TransferOwnershipRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	g, err := t.DesignerService.TransferOwnership(uint(gameID), &req, userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to transfer ownership")
+		return
+	}
+
+	c.JSON(200, app.GameResponse{Game: g})
+}
+
+// DesignerHistory lists every change to who designs a game, oldest first. Designers only.
+// @Summary List every change to who designs a game, oldest first. Designers only.
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Success 200 {object} app.DesignerHistoryResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/designers/history [get]
+func (t *DesignerController) DesignerHistory(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	changes, err := t.DesignerService.DesignerHistory(uint(gameID), userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to fetch designer history")
+		return
+	}
+
+	c.JSON(200, app.DesignerHistoryResponse{Changes: changes})
+}
+
+// MyInvitations lists the invitations waiting on the current user's answer
+// @Summary List the invitations waiting on the current user's answer
+// @Produce json
+// @Success 200 {object} app.ListInvitationsResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags invitations
+// @Router /invitations [get]
+func (t *DesignerController) MyInvitations(c *gin.Context) {
+	userID := userID(c)
+
+	invitations, err := t.DesignerService.MyInvitations(userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to fetch invitations")
+		return
+	}
+
+	c.JSON(200, app.ListInvitationsResponse{Invitations: invitations})
+}
+
+// AcceptInvitation makes the current user a designer of the game they were invited to
+// @Summary Become a designer of the game you were invited to
+// @Produce json
+// @Param id path integer true "Invitation ID"
+// @Success 200 {object} app.InvitationResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags invitations
+// @Router /invitations/:id/accept [put]
+func (t *DesignerController) AcceptInvitation(c *gin.Context) {
+	// Pull invitation by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	invitation, err := t.DesignerService.AcceptInvitation(uint(id), userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to accept invitation")
+		return
+	}
+
+	c.JSON(200, app.InvitationResponse{Invitation: invitation})
+}
+
+// DeclineInvitation turns down an invitation to co-design a game
+// @Summary Turn down an invitation to co-design a game
+// @Produce json
+// @Param id path integer true "Invitation ID"
+// @Success 200 {object} app.InvitationResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags invitations
+// @Router /invitations/:id/decline [put]
+func (t *DesignerController) DeclineInvitation(c *gin.Context) {
+	// Pull invitation by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	invitation, err := t.DesignerService.DeclineInvitation(uint(id), userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to decline invitation")
+		return
+	}
+
+	c.JSON(200, app.InvitationResponse{Invitation: invitation})
+}
+
+// CancelInvitation withdraws an invitation that hasn't been answered yet. Designers only.
+// @Summary Withdraw an invitation that hasn't been answered yet. Designers only.
+// @Produce json
+// @Param id path integer true "Invitation ID"
+// @Success 200 {object} app.InvitationResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags invitations
+// @Router /invitations/:id/cancel [put]
+func (t *DesignerController) CancelInvitation(c *gin.Context) {
+	// Pull invitation by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	invitation, err := t.DesignerService.CancelInvitation(uint(id), userID)
+	if err != nil {
+		designerErrorResponse(c, err, "failed to cancel invitation")
+		return
+	}
+
+	c.JSON(200, app.InvitationResponse{Invitation: invitation})
+}
+
+func designerErrorResponse(c *gin.Context, err error, fallback string) {
+	if errors.As(err, &domain.Forbidden{}) {
+		forbiddenResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &domain.GameNotFound{}) || errors.As(err, &domain.InvitationNotFound{}) || errors.As(err, &domain.UserNotFound{}) {
+		notFoundResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &game.LastDesigner{}) || errors.As(err, &game.OwnerRemoval{}) || errors.As(err, &game.NotADesigner{}) ||
+		errors.As(err, &game.AlreadyDesigner{}) || errors.As(err, &game.AlreadyInvited{}) || errors.As(err, &game.InvitationClosed{}) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	serverErrorResponse(c, fallback)
+}
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
 	"github.com/gin-gonic/gin"
 )
 
@@ -132,3 +135,50 @@ func (t *EventController) UpdateEvent(c *gin.Context) {
 
 	c.JSON(200, app.EventResponse{Event: event})
 }
+
+// EventSlots returns the slots of every occurrence of an event on a date
+// @Summary Return the slots of every occurrence of an event on a date
+// @Produce json
+// @Param id path integer true "Event ID"
+// @Param query query app.EventSlotsRequest true "Date to list slots for"
+// @Success 200 {object} app.EventSlotsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags events
+// @Router /events/:id/slots [get]
+func (t *EventController) EventSlots(c *gin.Context) {
+	// Pull event by ID
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.EventSlotsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	slots, err := t.EventService.EventSlots(uint(eventID), &req)
+	if err != nil {
+		var perr *time.ParseError
+		if errors.As(err, &perr) {
+			requestErrorResponse(c, err.Error())
+			return
+		}
+
+		if errors.As(err, &domain.EventNotFound{}) {
+			notFoundResponse(c, err.Error())
+			return
+		}
+
+		serverErrorResponse(c, "failed to fetch slots")
+		return
+	}
+
+	c.JSON(200, app.EventSlotsResponse{Slots: slots})
+}
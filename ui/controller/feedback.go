@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/feedback"
+	"github.com/gin-gonic/gin"
+)
+
+// FeedbackController handles /playtests/:id/feedback routes
+type FeedbackController struct {
+	FeedbackService *app.FeedbackService
+}
+
+// LeaveFeedback records the authenticated player's feedback on a playtest
+// @Summary Record the authenticated player's feedback on a playtest
+// @Accept json
+// @Produce json
+// @Param id path integer true "Playtest ID"
+// @Param feedback body app.LeaveFeedbackRequest true "Feedback data"
+// @Success 200 {object} app.FeedbackResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /playtests/:id/feedback [put]
+func (t *FeedbackController) LeaveFeedback(c *gin.Context) {
+	// Pull playtest by ID
+	playtestID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.LeaveFeedbackRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	userID := userID(c)
+	response, err := t.FeedbackService.LeaveFeedback(uint(playtestID), &req, userID)
+	if err != nil {
+		if errors.As(err, &feedback.NotOpen{}) || errors.As(err, &feedback.NotAPlayer{}) || errors.As(err, &feedback.InvalidRating{}) {
+			requestErrorResponse(c, err.Error())
+			return
+		}
+
+		playtestErrorResponse(c, err, "failed to leave feedback")
+		return
+	}
+
+	c.JSON(200, app.FeedbackResponse{Feedback: response})
+}
+
+// PlaytestFeedback returns the aggregate and individual feedback for a playtest
+// @Summary Return the aggregate and individual feedback for a playtest. Only available to designers.
+// @Produce json
+// @Param id path integer true "Playtest ID"
+// @Success 200 {object} app.PlaytestFeedbackResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /playtests/:id/feedback [get]
+func (t *FeedbackController) PlaytestFeedback(c *gin.Context) {
+	// Pull playtest by ID
+	playtestID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+	summary, responses, err := t.FeedbackService.PlaytestFeedback(uint(playtestID), userID)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to fetch feedback")
+		return
+	}
+
+	c.JSON(200, app.PlaytestFeedbackResponse{Summary: *summary, Responses: responses})
+}
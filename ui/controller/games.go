@@ -1,9 +1,17 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"strconv"
+	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/rulebook"
 	"github.com/gin-gonic/gin"
 )
 
@@ -12,8 +20,8 @@ type GameController struct {
 	GameService *app.GameService
 }
 
-// ListGames list games matching the query with pagination
-// @Summary List games matching the query with pagination
+// ListGames list games matching the query with pagination. Full-text searches (q) are ranked by relevance.
+// @Summary List games matching the query with pagination, optionally ranked by a full-text search
 // @Accept json
 // @Produce json
 // @Param query query app.ListGamesRequest false "Filters for games"
@@ -32,14 +40,14 @@ func (t *GameController) ListGames(c *gin.Context) {
 	}
 
 	// Fetch games
-	games, total, err := t.GameService.ListGames(&req)
+	games, highlights, total, err := t.GameService.ListGames(&req)
 
 	if err != nil {
 		serverErrorResponse(c, "failed to fetch games")
 		return
 	}
 
-	c.JSON(200, app.ListGamesResponse{Games: games, Total: total, Limit: req.Limit, Offset: req.Offset})
+	c.JSON(200, app.ListGamesResponse{Games: games, Highlights: highlights, Total: total, Limit: req.Limit, Offset: req.Offset})
 }
 
 // CreateGame creates a new stub game
@@ -63,13 +71,13 @@ func (t *GameController) CreateGame(c *gin.Context) {
 
 	// Create our new game
 	userID := userID(c)
-	game, err := t.GameService.CreateGame(&req, userID)
+	game, rejected, err := t.GameService.CreateGame(&req, userID)
 	if err != nil {
 		serverErrorResponse(c, "failed to create game")
 		return
 	}
 
-	c.JSON(201, app.GameResponse{Game: game})
+	c.JSON(201, app.GameResponse{Game: game, Rejected: rejected})
 }
 
 // GetGame returns a specific game by id
@@ -135,7 +143,7 @@ func (t *GameController) GetRules(c *gin.Context) {
 }
 
 // UpdateGame updates a specific game
-// @Summary Update a specific game
+// @Summary Update a specific game. Status has its own endpoint, see PUT /games/:id/status.
 // @Accept json
 // @Produce json
 // @Param id path integer true "Game ID"
@@ -163,26 +171,610 @@ func (t *GameController) UpdateGame(c *gin.Context) {
 		return
 	}
 
-	game, err := t.GameService.UpdateGame(uint(gameID), &req, userID)
+	if req.Status != "" {
+		c.AbortWithStatusJSON(400, ValidationErrorResponse{Errors: map[string]string{"status": "status can't be updated here, use PUT /games/:id/status instead"}})
+		return
+	}
+
+	g, rejected, err := t.GameService.UpdateGame(uint(gameID), &req, userID)
 	if err != nil {
+		var uerr game.UnknownMechanics
+		if errors.As(err, &uerr) {
+			c.AbortWithStatusJSON(400, ValidationErrorResponse{Errors: map[string]string{"mechanics": uerr.Error()}})
+			return
+		}
+
 		serverErrorResponse(c, "failed to update game")
 		return
 	}
 
-	c.JSON(200, app.GameResponse{Game: game})
+	c.JSON(200, app.GameResponse{Game: g, Rejected: rejected})
+}
+
+// CreateRulesSection adds a section to the end of a game's rules
+// @Summary Add a section to the end of a game's rules
+// @Accept json
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param section body app.CreateRulesSectionRequest true "Section details"
+// @Success 200 {object} app.RulesSectionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules [post]
+func (t *GameController) CreateRulesSection(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.CreateRulesSectionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	section, rejected, err := t.GameService.CreateRulesSection(uint(gameID), &req, userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to create rules section")
+		return
+	}
+
+	c.JSON(200, app.RulesSectionResponse{Section: section, Rejected: rejected})
+}
+
+// UpdateRulesSection changes the title and/or content of a section of a game's rules
+// @Summary Change the title and/or content of a section of a game's rules
+// @Accept json
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param section path integer true "Section ID"
+// @Param changes body app.UpdateRulesSectionRequest true "Section details"
+// @Success 200 {object} app.RulesSectionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules/:section [put]
+func (t *GameController) UpdateRulesSection(c *gin.Context) {
+	// Pull game and section by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	sectionID, err := strconv.ParseUint(c.Param("section"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.UpdateRulesSectionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	section, rejected, err := t.GameService.UpdateRulesSection(uint(gameID), uint(sectionID), &req, userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to update rules section")
+		return
+	}
+
+	c.JSON(200, app.RulesSectionResponse{Section: section, Rejected: rejected})
+}
+
+// DeleteRulesSection removes a section from a game's rules
+// @Summary Remove a section from a game's rules
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param section path integer true "Section ID"
+// @Success 200 {object} AckResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules/:section [delete]
+func (t *GameController) DeleteRulesSection(c *gin.Context) {
+	// Pull game and section by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	sectionID, err := strconv.ParseUint(c.Param("section"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	err = t.GameService.DeleteRulesSection(uint(gameID), uint(sectionID), userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to delete rules section")
+		return
+	}
+
+	ackResponse(c)
 }
 
-// ListAvailableMechanics lists mechanics available to be applied to games
-// @Summary List mechanics available to be applied to games
+// ReorderRules rearranges every section of a game's rules at once
+// @Summary Rearrange every section of a game's rules at once
 // @Accept json
 // @Produce json
-// @Success 200 {object} app.ListMechanicsResponse
+// @Param id path integer true "Game ID"
+// @Param order body app.ReorderRulesRequest true "Every section ID in its new order"
+// @Success 200 {object} app.RulesResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules [put]
+func (t *GameController) ReorderRules(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.ReorderRulesRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	rules, err := t.GameService.ReorderRules(uint(gameID), &req, userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to reorder rules")
+		return
+	}
+
+	c.JSON(200, app.RulesResponse{Rules: rules})
+}
+
+// ImportRules splits an uploaded Markdown document into sections and merges them into, or replaces, a game's rules
+// @Summary Import a game's rules from a Markdown document, or preview the import with a dry run
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param file formData file true "Markdown document"
+// @Param mode formData string false "merge (default) or replace"
+// @Param dry_run formData boolean false "Preview the import without saving it"
+// @Success 200 {object} app.ImportRulesResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules/import [post]
+func (t *GameController) ImportRules(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.ImportRulesRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	document, err := uploadedDocument(c, "file")
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	result, err := t.GameService.ImportRules(uint(gameID), &req, document, userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to import rules")
+		return
+	}
+
+	c.JSON(200, app.ImportRulesResponse{DryRun: req.DryRun, Import: result})
+}
+
+// ListRevisions lists the history of a game's rules with pagination, newest first
+// @Summary List the history of a game's rules with pagination, newest first
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param query query app.ListRevisionsRequest false "Filters for revisions"
+// @Success 200 {object} app.ListRevisionsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules/revisions [get]
+func (t *GameController) ListRevisions(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.ListRevisionsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	revisions, total, err := t.GameService.ListRevisions(uint(gameID), &req)
+	if err != nil {
+		serverErrorResponse(c, "failed to fetch revisions")
+		return
+	}
+
+	c.JSON(200, app.ListRevisionsResponse{Revisions: revisions, Total: total, Limit: req.Limit, Offset: req.Offset})
+}
+
+// RulesDiff compares a game's rules at two revisions or dates, section by section
+// @Summary Compare a game's rules at two revisions or dates, section by section
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param query query app.RulesDiffRequest true "Points in time to compare"
+// @Success 200 {object} app.RulesDiffResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags games
-// @Router /games/available-mechanics [get]
-func (t *GameController) ListAvailableMechanics(c *gin.Context) {
-	mechanics := t.GameService.ListAvailableMechanics()
+// @Router /games/:id/rules/diff [get]
+func (t *GameController) RulesDiff(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.RulesDiffRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	sections, err := t.GameService.RulesDiff(uint(gameID), &req)
+	if err != nil {
+		var perr *time.ParseError
+		if errors.As(err, &perr) {
+			requestErrorResponse(c, err.Error())
+			return
+		}
+
+		gameErrorResponse(c, err, "failed to compare rules")
+		return
+	}
+
+	c.JSON(200, app.RulesDiffResponse{Sections: sections})
+}
+
+// ExportRules downloads a game's rules as a single Markdown, HTML or PDF document
+// @Summary Download a game's rules as a single Markdown, HTML or PDF document
+// @Produce text/markdown
+// @Produce html
+// @Produce application/pdf
+// @Param id path integer true "Game ID"
+// @Param query query app.ExportRulesRequest true "Document format"
+// @Success 200 {file} file
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules/export [get]
+func (t *GameController) ExportRules(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.ExportRulesRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	g, document, err := t.GameService.ExportRules(uint(gameID), &req)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to export rules")
+		return
+	}
+
+	format := rulebook.Format(req.Format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, rulebook.Filename(g.Title, format)))
+	c.Data(200, format.ContentType(), document)
+}
+
+// RestoreRevision puts a section of a game's rules back the way it was at an earlier revision
+// @Summary Put a section of a game's rules back the way it was at an earlier revision
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param revision path integer true "Revision ID"
+// @Success 200 {object} app.RulesSectionResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/rules/revisions/:revision/restore [post]
+func (t *GameController) RestoreRevision(c *gin.Context) {
+	// Pull game and revision by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	revisionID, err := strconv.ParseUint(c.Param("revision"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	section, err := t.GameService.RestoreRevision(uint(gameID), uint(revisionID), userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to restore revision")
+		return
+	}
+
+	c.JSON(200, app.RulesSectionResponse{Section: section})
+}
+
+// ChangeStatus moves a game along its lifecycle, recording the change in its status history
+// @Summary Move a game along its lifecycle, recording the change in its status history
+// @Accept json
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param status body app.ChangeStatusRequest true "Status change"
+// @Success 200 {object} app.StatusChangeResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/status [put]
+func (t *GameController) ChangeStatus(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.ChangeStatusRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	g, change, err := t.GameService.ChangeStatus(uint(gameID), &req, userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to change status")
+		return
+	}
+
+	c.JSON(200, app.StatusChangeResponse{Game: g, Change: change})
+}
+
+// StatusHistory lists every status change of a game, oldest first
+// @Summary List every status change of a game, oldest first
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Success 200 {object} app.StatusHistoryResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/status [get]
+func (t *GameController) StatusHistory(c *gin.Context) {
+	// Validate request
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	changes, err := t.GameService.StatusHistory(uint(id))
+	if err != nil {
+		serverErrorResponse(c, "failed to fetch status history")
+		return
+	}
+
+	c.JSON(200, app.StatusHistoryResponse{Changes: changes})
+}
+
+// CreateVersion snapshots the current state of a game as a named version
+// @Summary Snapshot the current state of a game as a named version
+// @Accept json
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param version body app.CreateVersionRequest true "Version details"
+// @Success 200 {object} app.VersionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/versions [post]
+func (t *GameController) CreateVersion(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.CreateVersionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	version, err := t.GameService.CreateVersion(uint(gameID), &req, userID)
+	if err != nil {
+		gameErrorResponse(c, err, "failed to create version")
+		return
+	}
+
+	c.JSON(200, app.VersionResponse{Version: version})
+}
+
+// ListVersions lists the versions of a game, newest first
+// @Summary List the versions of a game, newest first
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Success 200 {object} app.ListVersionsResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/versions [get]
+func (t *GameController) ListVersions(c *gin.Context) {
+	// Validate request
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	versions, err := t.GameService.ListVersions(uint(id))
+	if err != nil {
+		serverErrorResponse(c, "failed to fetch versions")
+		return
+	}
+
+	c.JSON(200, app.ListVersionsResponse{Versions: versions})
+}
+
+// GetVersion returns a specific version of a game, including its rules and files
+// @Summary Return a specific version of a game, including its rules and files
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param version path integer true "Version ID"
+// @Success 200 {object} app.VersionResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/versions/:version [get]
+func (t *GameController) GetVersion(c *gin.Context) {
+	// Validate request
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	versionID, err := strconv.ParseUint(c.Param("version"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	version, err := t.GameService.GetVersion(uint(id), uint(versionID))
+	if err != nil {
+		serverErrorResponse(c, "failed to fetch version")
+		return
+	}
+
+	if version == nil {
+		notFoundResponse(c, "version not found")
+		return
+	}
+
+	c.JSON(200, app.VersionResponse{Version: version})
+}
+
+func gameErrorResponse(c *gin.Context, err error, fallback string) {
+	if errors.As(err, &domain.Forbidden{}) {
+		forbiddenResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &domain.GameNotFound{}) || errors.As(err, &game.SectionNotFound{}) || errors.As(err, &game.RevisionNotFound{}) || errors.As(err, &domain.PublisherNotFound{}) {
+		notFoundResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &game.InvalidVersion{}) || errors.As(err, &game.InvalidOrder{}) || errors.As(err, &rulebook.EmptyDocument{}) ||
+		errors.As(err, &game.InvalidTransition{}) || errors.As(err, &game.ContractRequired{}) || errors.As(err, &publisher.NotAccepted{}) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	serverErrorResponse(c, fallback)
+}
+
+// maxDocumentSize is as large a document as we'll accept for import
+const maxDocumentSize = 1 << 20
+
+// uploadedDocument reads a small text file sent as part of a multipart form
+func uploadedDocument(c *gin.Context, field string) ([]byte, error) {
+	header, err := c.FormFile(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Size > maxDocumentSize {
+		return nil, fmt.Errorf("%s must be smaller than %d bytes", field, maxDocumentSize)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 
-	c.JSON(200, app.ListMechanicsResponse{Mechanics: mechanics})
+	return ioutil.ReadAll(file)
 }
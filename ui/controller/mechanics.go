@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/gin-gonic/gin"
+)
+
+// MechanicController handles /mechanics routes
+type MechanicController struct {
+	MechanicService *app.MechanicService
+}
+
+// ListMechanics lists the mechanics taxonomy, with how many games use each mechanic
+// @Summary List the mechanics taxonomy, with how many games use each mechanic
+// @Accept json
+// @Produce json
+// @Success 200 {object} app.ListMechanicsResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags mechanics
+// @Router /mechanics [get]
+func (t *MechanicController) ListMechanics(c *gin.Context) {
+	mechanics, err := t.MechanicService.ListMechanics()
+	if err != nil {
+		serverErrorResponse(c, "failed to fetch mechanics")
+		return
+	}
+
+	c.JSON(200, app.ListMechanicsResponse{Mechanics: mechanics})
+}
+
+// CreateMechanic adds a mechanic to the taxonomy. Admins only.
+// @Summary Add a mechanic to the taxonomy. Admins only.
+// @Accept json
+// @Produce json
+// @Param mechanic body app.CreateMechanicRequest true "Mechanic details"
+// @Success 201 {object} app.MechanicResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags mechanics
+// @Router /mechanics [post]
+func (t *MechanicController) CreateMechanic(c *gin.Context) {
+	userID := userID(c)
+
+	// Validate request
+	var req app.CreateMechanicRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	mechanic, err := t.MechanicService.CreateMechanic(&req, userID)
+	if err != nil {
+		mechanicErrorResponse(c, err, "failed to create mechanic")
+		return
+	}
+
+	c.JSON(201, app.MechanicResponse{Mechanic: mechanic})
+}
+
+// MergeMechanic folds a mechanic into another, retagging every game that used it. Admins only.
+// @Summary Fold a mechanic into another, retagging every game that used it. Admins only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "ID of the mechanic to merge away"
+// @Param merge body app.MergeMechanicRequest true "Mechanic to merge into"
+// @Success 200 {object} app.MechanicResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags mechanics
+// @Router /mechanics/:id/merge [post]
+func (t *MechanicController) MergeMechanic(c *gin.Context) {
+	mechanicID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate request
+	var req app.MergeMechanicRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	mechanic, err := t.MechanicService.MergeMechanic(uint(mechanicID), &req, userID)
+	if err != nil {
+		mechanicErrorResponse(c, err, "failed to merge mechanic")
+		return
+	}
+
+	c.JSON(200, app.MechanicResponse{Mechanic: mechanic})
+}
+
+func mechanicErrorResponse(c *gin.Context, err error, fallback string) {
+	if errors.As(err, &domain.Forbidden{}) {
+		forbiddenResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &domain.MechanicNotFound{}) {
+		notFoundResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &game.InvalidMechanic{}) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	serverErrorResponse(c, fallback)
+}
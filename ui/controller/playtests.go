@@ -1,29 +1,50 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/credit"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/event"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/playtest"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/ui/events"
 	"github.com/gin-gonic/gin"
 )
 
 // PlaytestController handles /playtests routes
 type PlaytestController struct {
 	PlaytestService *app.PlaytestService
+	Board           *events.PlaytestBoard
 }
 
+// boardHeartbeat keeps idle board streams from being closed by proxies along the way
+const boardHeartbeat = 30 * time.Second
+
 // PlaytestsOnDate returns all the playtests scheduled for the provided date. Optionally by event.
+// Clients that accept text/event-stream get the board streamed to them instead, see streamPlaytestsOnDate.
 // @Summary Return all the playtests scheduled for the provided date. Optionally by event.
 // @Accept json
 // @Produce json
+// @Produce text/event-stream
 // @Param query query app.ListPlaytestsRequest false "Filters for playtests"
-// @Success 200 {object} app.ListPlaytestsResponse
+// @Success 200 {object} app.PlaytestsOnDateResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests [get]
 func (t *PlaytestController) PlaytestsOnDate(c *gin.Context) {
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		t.streamPlaytestsOnDate(c)
+		return
+	}
+
 	// Validate request
 	var req app.ListPlaytestsRequest
 	if err := c.ShouldBind(&req); err != nil {
@@ -32,14 +53,112 @@ func (t *PlaytestController) PlaytestsOnDate(c *gin.Context) {
 	}
 
 	// Fetch playtests
-	playtests, err := t.PlaytestService.ListPlaytests(&req)
+	playtests, err := t.PlaytestService.ListPlaytests(&req, optionalUserID(c))
 
 	if err != nil {
-		serverErrorResponse(c, "failed to fetch playtests")
+		playtestErrorResponse(c, err, "failed to fetch playtests")
 		return
 	}
 
-	c.JSON(200, app.ListPlaytestsResponse{Playtests: playtests})
+	c.JSON(200, app.PlaytestsOnDateResponse{Playtests: playtests, Slots: domain.GroupPlaytestsBySlot(playtests)})
+}
+
+// streamPlaytestsOnDate streams the playtests scheduled for the provided date as server-sent events. Optionally by event.
+// The whole board is sent as a "board" event when the stream opens and again whenever any of its playtests change.
+func (t *PlaytestController) streamPlaytestsOnDate(c *gin.Context) {
+	// Validate request
+	var req app.ListPlaytestsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	day, _, err := t.PlaytestService.PlaytestDay(req.EventID, req.Date)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to fetch playtests")
+		return
+	}
+
+	// Start watching before the first fetch, so no changes slip through in between
+	watcher := t.Board.Watch(req.EventID, day)
+	defer t.Board.Stop(watcher)
+
+	userID := optionalUserID(c)
+	playtests, err := t.PlaytestService.ListPlaytests(&req, userID)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to fetch playtests")
+		return
+	}
+
+	heartbeat := time.NewTicker(boardHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("board", app.PlaytestsOnDateResponse{Playtests: playtests, Slots: domain.GroupPlaytestsBySlot(playtests)})
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", time.Now().Unix())
+		case <-watcher.Updates:
+			playtests, err := t.PlaytestService.ListPlaytests(&req, userID)
+			if err != nil {
+				c.SSEvent("error", "failed to fetch playtests")
+				return false
+			}
+
+			c.SSEvent("board", app.PlaytestsOnDateResponse{Playtests: playtests, Slots: domain.GroupPlaytestsBySlot(playtests)})
+		}
+
+		return true
+	})
+}
+
+// GamePlaytests returns a game's playtest history with pagination, along with metrics for the whole history
+// @Summary Return a game's playtest history with pagination, along with metrics for the whole history
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Param query query app.GamePlaytestsRequest false "Filters for playtests"
+// @Success 200 {object} app.GamePlaytestsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /games/:id/playtests [get]
+func (t *PlaytestController) GamePlaytests(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.GamePlaytestsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	// Fetch playtests
+	playtests, total, metrics, err := t.PlaytestService.GamePlaytests(uint(gameID), &req, optionalUserID(c))
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to fetch playtests")
+		return
+	}
+
+	c.JSON(200, app.GamePlaytestsResponse{
+		Playtests: playtests,
+		Total:     total,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+		Metrics:   *metrics,
+	})
 }
 
 // RegisterGame schedules a playtest for a particular game
@@ -50,6 +169,8 @@ func (t *PlaytestController) PlaytestsOnDate(c *gin.Context) {
 // @Success 201 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/register-game [post]
@@ -65,13 +186,103 @@ func (t *PlaytestController) RegisterGame(c *gin.Context) {
 	userID := userID(c)
 	playtest, err := t.PlaytestService.RegisterGame(&req, userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to register game")
+		if errors.As(err, &credit.InsufficientCredits{}) {
+			requestErrorResponse(c, err.Error())
+			return
+		}
+
+		playtestErrorResponse(c, err, "failed to register game")
 		return
 	}
 
 	c.JSON(201, app.PlaytestResponse{Playtest: playtest})
 }
 
+// ProposeTablePlan proposes a table for every registered playtest at an event on a date
+// @Summary Propose a table for every registered playtest at an event on a date. Nothing is saved until the plan is committed.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Event ID"
+// @Param plan body app.ProposeTablePlanRequest true "Date and available tables"
+// @Success 200 {object} app.TablePlanResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /events/:id/table-plan [post]
+func (t *PlaytestController) ProposeTablePlan(c *gin.Context) {
+	// Pull event by ID
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.ProposeTablePlanRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	userID := userID(c)
+	plan, flagged, err := t.PlaytestService.ProposeTablePlan(uint(eventID), &req, userID)
+	if err != nil {
+		var perr *time.ParseError
+		if errors.As(err, &perr) {
+			requestErrorResponse(c, err.Error())
+			return
+		}
+
+		playtestErrorResponse(c, err, "failed to plan tables")
+		return
+	}
+
+	c.JSON(200, app.TablePlanResponse{Plan: *plan, Flagged: flagged})
+}
+
+// CommitTablePlan assigns playtests to the tables in a (possibly tweaked) plan
+// @Summary Assign playtests to the tables in a (possibly tweaked) plan
+// @Accept json
+// @Produce json
+// @Param id path integer true "Event ID"
+// @Param plan body app.CommitTablePlanRequest true "Table assignments"
+// @Success 200 {object} app.ListPlaytestsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /events/:id/table-plan [put]
+func (t *PlaytestController) CommitTablePlan(c *gin.Context) {
+	// Pull event by ID
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.CommitTablePlanRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	userID := userID(c)
+	playtests, err := t.PlaytestService.CommitTablePlan(uint(eventID), &req, userID)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to assign tables")
+		return
+	}
+
+	c.JSON(200, app.ListPlaytestsResponse{Playtests: playtests})
+}
+
 // AssignLocation assigns a playtest to a table (real or virtual)
 // @Summary Assign a playtest to a table (real or virtual)
 // @Accept json
@@ -81,6 +292,9 @@ func (t *PlaytestController) RegisterGame(c *gin.Context) {
 // @Success 200 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/:id/location [put]
@@ -103,7 +317,7 @@ func (t *PlaytestController) AssignLocation(c *gin.Context) {
 	userID := userID(c)
 	playtest, err := t.PlaytestService.AssignLocation(uint(playtestID), &req, userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to assign location")
+		playtestErrorResponse(c, err, "failed to assign location")
 		return
 	}
 
@@ -118,6 +332,9 @@ func (t *PlaytestController) AssignLocation(c *gin.Context) {
 // @Success 200 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/:id/location [put]
@@ -129,11 +346,11 @@ func (t *PlaytestController) AddPlayer(c *gin.Context) {
 		return
 	}
 
-	// Assign the location
+	// Join the playtest
 	userID := userID(c)
 	playtest, err := t.PlaytestService.AddPlayer(uint(playtestID), userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to join playtest")
+		playtestErrorResponse(c, err, "failed to join playtest")
 		return
 	}
 
@@ -148,6 +365,8 @@ func (t *PlaytestController) AddPlayer(c *gin.Context) {
 // @Success 200 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/:id/location [put]
@@ -159,25 +378,68 @@ func (t *PlaytestController) RemovePlayer(c *gin.Context) {
 		return
 	}
 
-	// Assign the location
+	// Leave the playtest
 	userID := userID(c)
 	playtest, err := t.PlaytestService.RemovePlayer(uint(playtestID), userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to leave playtest")
+		playtestErrorResponse(c, err, "failed to leave playtest")
+		return
+	}
+
+	c.JSON(200, app.PlaytestResponse{Playtest: playtest})
+}
+
+// RotateTTSPassword replaces the password for a playtest's TTS server
+// @Summary Replace the password for a playtest's TTS server. Leave the password blank to generate one. Only designers may rotate it.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Playtest ID"
+// @Param password body app.RotateTTSPasswordRequest false "New password"
+// @Success 200 {object} app.PlaytestResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /playtests/:id/tts-password [put]
+func (t *PlaytestController) RotateTTSPassword(c *gin.Context) {
+	// Pull playtest by ID
+	playtestID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.RotateTTSPasswordRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	userID := userID(c)
+	playtest, err := t.PlaytestService.RotateTTSPassword(uint(playtestID), &req, userID)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to rotate password")
 		return
 	}
 
 	c.JSON(200, app.PlaytestResponse{Playtest: playtest})
 }
 
-// Start marks the time the playtest started
-// @Summary  marks the time the playtest started
+// Start marks the time the playtest started and checks in the players who showed up
+// @Summary  marks the time the playtest started and checks in the players who showed up. Players left out of attendees are marked as no-shows.
 // @Accept json
 // @Produce json
 // @Param id path integer true "Playtest ID"
+// @Param attendees body app.StartPlaytestRequest false "Players who showed up. Leave out to check in everyone."
 // @Success 200 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/:id/start [put]
@@ -189,11 +451,19 @@ func (t *PlaytestController) Start(c *gin.Context) {
 		return
 	}
 
-	// Assign the location
+	// Attendees are optional, so an empty body is fine
+	var req app.StartPlaytestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(&req); err != nil {
+			validationErrorResponse(c, err)
+			return
+		}
+	}
+
 	userID := userID(c)
-	playtest, err := t.PlaytestService.StartPlaytest(uint(playtestID), userID)
+	playtest, err := t.PlaytestService.StartPlaytest(uint(playtestID), &req, userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to assign location")
+		playtestErrorResponse(c, err, "failed to start playtest")
 		return
 	}
 
@@ -208,6 +478,9 @@ func (t *PlaytestController) Start(c *gin.Context) {
 // @Success 200 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/:id/start-feedback [put]
@@ -219,25 +492,28 @@ func (t *PlaytestController) StartFeedback(c *gin.Context) {
 		return
 	}
 
-	// Assign the location
 	userID := userID(c)
 	playtest, err := t.PlaytestService.StartFeedback(uint(playtestID), userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to assign location")
+		playtestErrorResponse(c, err, "failed to start feedback")
 		return
 	}
 
 	c.JSON(200, app.PlaytestResponse{Playtest: playtest})
 }
 
-// Finish marks the time the playtest ended
-// @Summary  marks the time the playtest ended
+// Finish marks the time the playtest ended, optionally recording how the game turned out
+// @Summary  marks the time the playtest ended, optionally recording how the game turned out
 // @Accept json
 // @Produce json
 // @Param id path integer true "Playtest ID"
+// @Param outcome body app.FinishPlaytestRequest false "How the game ended and how each player fared"
 // @Success 200 {object} app.PlaytestResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
 // @Failure 500 {object} ServerErrorResponse
 // @Tags playtests
 // @Router /playtests/:id/finish [put]
@@ -249,13 +525,137 @@ func (t *PlaytestController) Finish(c *gin.Context) {
 		return
 	}
 
-	// Assign the location
+	// The outcome is optional, so an empty body is fine
+	var req app.FinishPlaytestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(&req); err != nil {
+			validationErrorResponse(c, err)
+			return
+		}
+	}
+
 	userID := userID(c)
-	playtest, err := t.PlaytestService.FinishPlaytest(uint(playtestID), userID)
+	playtest, err := t.PlaytestService.FinishPlaytest(uint(playtestID), &req, userID)
 	if err != nil {
-		serverErrorResponse(c, "failed to assign location")
+		playtestErrorResponse(c, err, "failed to finish playtest")
 		return
 	}
 
 	c.JSON(200, app.PlaytestResponse{Playtest: playtest})
 }
+
+// Cancel calls off a playtest that hasn't started yet, letting its players know why
+// @Summary Call off a playtest that hasn't started yet, letting its players know why
+// @Accept json
+// @Produce json
+// @Param id path integer true "Playtest ID"
+// @Param cancellation body app.CancelPlaytestRequest true "Why the playtest was called off"
+// @Success 200 {object} app.PlaytestResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /playtests/:id/cancel [put]
+func (t *PlaytestController) Cancel(c *gin.Context) {
+	// Pull playtest by ID
+	playtestID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.CancelPlaytestRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	userID := userID(c)
+	playtest, err := t.PlaytestService.CancelPlaytest(uint(playtestID), &req, userID)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to cancel playtest")
+		return
+	}
+
+	c.JSON(200, app.PlaytestResponse{Playtest: playtest})
+}
+
+// Reschedule moves a playtest that hasn't started yet to another date, letting its players know why
+// @Summary Move a playtest that hasn't started yet to another date, letting its players know why
+// @Accept json
+// @Produce json
+// @Param id path integer true "Playtest ID"
+// @Param schedule body app.ReschedulePlaytestRequest true "The new date and why the playtest moved"
+// @Success 200 {object} app.PlaytestResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 403 {object} UnauthorizedResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 409 {object} ConflictResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags playtests
+// @Router /playtests/:id/reschedule [put]
+func (t *PlaytestController) Reschedule(c *gin.Context) {
+	// Pull playtest by ID
+	playtestID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	// Validate request
+	var req app.ReschedulePlaytestRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	userID := userID(c)
+	playtest, err := t.PlaytestService.ReschedulePlaytest(uint(playtestID), &req, userID)
+	if err != nil {
+		playtestErrorResponse(c, err, "failed to reschedule playtest")
+		return
+	}
+
+	c.JSON(200, app.PlaytestResponse{Playtest: playtest})
+}
+
+// playtestErrorResponse picks the right response for errors coming back from the playtest service
+func playtestErrorResponse(c *gin.Context, err error, fallback string) {
+	if errors.As(err, &domain.Forbidden{}) {
+		forbiddenResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &domain.PlaytestNotFound{}) || errors.As(err, &domain.GameNotFound{}) || errors.As(err, &domain.EventNotFound{}) || errors.As(err, &domain.UserNotFound{}) {
+		notFoundResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &playtest.InvalidTransition{}) || errors.As(err, &playtest.NotAcceptingPlayers{}) {
+		conflictResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &playtest.InvalidOutcome{}) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &event.NotScheduled{}) || errors.As(err, &event.InvalidSlot{}) || errors.As(err, &playtest.DateInPast{}) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	var perr *time.ParseError
+	if errors.As(err, &perr) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	serverErrorResponse(c, fallback)
+}
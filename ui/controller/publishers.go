@@ -0,0 +1,509 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/game"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain/publisher"
+	"github.com/gin-gonic/gin"
+)
+
+// PublisherController handles /publishers and /submissions routes
+type PublisherController struct {
+	PublisherService *app.PublisherService
+}
+
+// ListPublishers lists publishers, optionally only those looking for games like a particular one
+// @Summary List publishers, optionally only those looking for games like a particular one
+// @Produce json
+// @Param query query app.ListPublishersRequest false "Filters for publishers"
+// @Success 200 {object} app.ListPublishersResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers [get]
+func (t *PublisherController) ListPublishers(c *gin.Context) {
+	// Validate request
+	var req app.ListPublishersRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	publishers, err := t.PublisherService.ListPublishers(&req)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to fetch publishers")
+		return
+	}
+
+	c.JSON(200, app.ListPublishersResponse{Publishers: publishers})
+}
+
+// CreatePublisher creates a publisher, with its owner as the first member. Admins only.
+// @Summary Create a publisher, with its owner as the first member. Admins only.
+// @Accept json
+// @Produce json
+// @Param publisher body app.CreatePublisherRequest true "Publisher details"
+// @Success 201 {object} app.PublisherResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers [post]
+func (t *PublisherController) CreatePublisher(c *gin.Context) {
+	userID := userID(c)
+
+	// Validate request
+	var req app.CreatePublisherRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	p, err := t.PublisherService.CreatePublisher(&req, userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to create publisher")
+		return
+	}
+
+	c.JSON(201, app.PublisherResponse{Publisher: p})
+}
+
+// GetPublisher returns a specific publisher, including what it's looking for
+// @Summary Return a specific publisher, including what it's looking for
+// @Produce json
+// @Param id path integer true "Publisher ID"
+// @Success 200 {object} app.PublisherResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers/:id [get]
+func (t *PublisherController) GetPublisher(c *gin.Context) {
+	// Validate request
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	p, err := t.PublisherService.GetPublisher(uint(id))
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to fetch publisher")
+		return
+	}
+
+	c.JSON(200, app.PublisherResponse{Publisher: p})
+}
+
+// UpdatePublisher updates a publisher and what it's looking for. Members only.
+// @Summary Update a publisher and what it's looking for. Members only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Publisher ID"
+// @Param publisher body app.UpdatePublisherRequest false "Publisher data"
+// @Success 200 {object} app.PublisherResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers/:id [put]
+func (t *PublisherController) UpdatePublisher(c *gin.Context) {
+	// Pull publisher by ID
+	publisherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.UpdatePublisherRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	p, err := t.PublisherService.UpdatePublisher(uint(publisherID), &req, userID)
+	if err != nil {
+		var uerr game.UnknownMechanics
+		if errors.As(err, &uerr) {
+			c.AbortWithStatusJSON(400, ValidationErrorResponse{Errors: map[string]string{"criteria.mechanics": uerr.Error()}})
+			return
+		}
+
+		publisherErrorResponse(c, err, "failed to update publisher")
+		return
+	}
+
+	c.JSON(200, app.PublisherResponse{Publisher: p})
+}
+
+// AddMember adds someone to a publisher. Owner only.
+// @Summary Add someone to a publisher. Owner only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Publisher ID"
+// @Param member body app.AddMemberRequest true "New member"
+// @Success 200 {object} app.PublisherResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers/:id/members [post]
+func (t *PublisherController) AddMember(c *gin.Context) {
+	// Pull publisher by ID
+	publisherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.AddMemberRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	p, err := t.PublisherService.AddMember(uint(publisherID), &req, userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to add member")
+		return
+	}
+
+	c.JSON(200, app.PublisherResponse{Publisher: p})
+}
+
+// RemoveMember takes someone off a publisher. Members may leave on their own, while only the owner may
+// remove others.
+// @Summary Take someone off a publisher. Members may leave on their own, while only the owner may remove others.
+// @Produce json
+// @Param id path integer true "Publisher ID"
+// @Param user path integer true "Member's user ID"
+// @Success 200 {object} app.PublisherResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers/:id/members/:user [delete]
+func (t *PublisherController) RemoveMember(c *gin.Context) {
+	// Pull publisher by ID
+	publisherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("user"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	p, err := t.PublisherService.RemoveMember(uint(publisherID), uint(memberID), userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to remove member")
+		return
+	}
+
+	c.JSON(200, app.PublisherResponse{Publisher: p})
+}
+
+// ListSubmissions lists the games submitted to a publisher with pagination, oldest first. Members only.
+// @Summary List the games submitted to a publisher with pagination, oldest first. Members only.
+// @Produce json
+// @Param id path integer true "Publisher ID"
+// @Param query query app.ListSubmissionsRequest false "Filters for submissions"
+// @Success 200 {object} app.ListSubmissionsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers/:id/submissions [get]
+func (t *PublisherController) ListSubmissions(c *gin.Context) {
+	// Pull publisher by ID
+	publisherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate request
+	var req app.ListSubmissionsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	submissions, total, err := t.PublisherService.ListSubmissions(uint(publisherID), &req, userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to fetch submissions")
+		return
+	}
+
+	c.JSON(200, app.ListSubmissionsResponse{Submissions: submissions, Total: total, Limit: req.Limit, Offset: req.Offset})
+}
+
+// SubmitGame pitches a game to a publisher along with one of its sell sheets. Designers only.
+// @Summary Pitch a game to a publisher along with one of its sell sheets. Designers only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Publisher ID"
+// @Param submission body app.SubmitGameRequest true "Game to submit"
+// @Success 201 {object} app.SubmissionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags publishers
+// @Router /publishers/:id/submissions [post]
+func (t *PublisherController) SubmitGame(c *gin.Context) {
+	// Pull publisher by ID
+	publisherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.SubmitGameRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	submission, err := t.PublisherService.SubmitGame(uint(publisherID), &req, userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to submit game")
+		return
+	}
+
+	c.JSON(201, app.SubmissionResponse{Submission: submission})
+}
+
+// GameSubmissions lists every submission of a game, newest first. Designers only.
+// @Summary List every submission of a game, newest first. Designers only.
+// @Produce json
+// @Param id path integer true "Game ID"
+// @Success 200 {object} app.ListSubmissionsResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags games
+// @Router /games/:id/submissions [get]
+func (t *PublisherController) GameSubmissions(c *gin.Context) {
+	// Pull game by ID
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	submissions, err := t.PublisherService.GameSubmissions(uint(gameID), userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to fetch submissions")
+		return
+	}
+
+	c.JSON(200, app.ListSubmissionsResponse{Submissions: submissions, Total: len(submissions), Limit: len(submissions)})
+}
+
+// GetSubmission returns a specific submission to either side of it. Only the publisher sees its notes.
+// @Summary Return a specific submission to either side of it. Only the publisher sees its notes.
+// @Produce json
+// @Param id path integer true "Submission ID"
+// @Success 200 {object} app.SubmissionResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags submissions
+// @Router /submissions/:id [get]
+func (t *PublisherController) GetSubmission(c *gin.Context) {
+	// Validate request
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	submission, err := t.PublisherService.GetSubmission(uint(id), userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to fetch submission")
+		return
+	}
+
+	c.JSON(200, app.SubmissionResponse{Submission: submission})
+}
+
+// AdvanceSubmission moves a submission through the publisher's review. Accepting a submission signs its game.
+// Members only.
+// @Summary Move a submission through the publisher's review. Accepting a submission signs its game. Members only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Submission ID"
+// @Param stage body app.AdvanceSubmissionRequest true "Next stage, and decision if any"
+// @Success 200 {object} app.SubmissionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags submissions
+// @Router /submissions/:id/stage [put]
+func (t *PublisherController) AdvanceSubmission(c *gin.Context) {
+	// Pull submission by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.AdvanceSubmissionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	submission, err := t.PublisherService.AdvanceSubmission(uint(id), &req, userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to update submission")
+		return
+	}
+
+	c.JSON(200, app.SubmissionResponse{Submission: submission})
+}
+
+// AddSubmissionNote keeps a private note about a submission. Members only.
+// @Summary Keep a private note about a submission. Members only.
+// @Accept json
+// @Produce json
+// @Param id path integer true "Submission ID"
+// @Param note body app.AddSubmissionNoteRequest true "Note"
+// @Success 201 {object} app.SubmissionNoteResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags submissions
+// @Router /submissions/:id/notes [post]
+func (t *PublisherController) AddSubmissionNote(c *gin.Context) {
+	// Pull submission by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	// Validate the request itself
+	var req app.AddSubmissionNoteRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErrorResponse(c, err)
+		return
+	}
+
+	note, err := t.PublisherService.AddSubmissionNote(uint(id), &req, userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to add note")
+		return
+	}
+
+	c.JSON(201, app.SubmissionNoteResponse{Note: note})
+}
+
+// WithdrawSubmission pulls a submission from review before the publisher decides on it. Designers only.
+// @Summary Pull a submission from review before the publisher decides on it. Designers only.
+// @Produce json
+// @Param id path integer true "Submission ID"
+// @Success 200 {object} app.SubmissionResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 401 {object} UnauthorizedResponse
+// @Failure 403 {object} RequestErrorResponse
+// @Failure 404 {object} RequestErrorResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags submissions
+// @Router /submissions/:id/withdraw [put]
+func (t *PublisherController) WithdrawSubmission(c *gin.Context) {
+	// Pull submission by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	userID := userID(c)
+
+	submission, err := t.PublisherService.WithdrawSubmission(uint(id), userID)
+	if err != nil {
+		publisherErrorResponse(c, err, "failed to withdraw submission")
+		return
+	}
+
+	c.JSON(200, app.SubmissionResponse{Submission: submission})
+}
+
+func publisherErrorResponse(c *gin.Context, err error, fallback string) {
+	if errors.As(err, &domain.Forbidden{}) {
+		forbiddenResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &domain.PublisherNotFound{}) || errors.As(err, &domain.SubmissionNotFound{}) || errors.As(err, &domain.GameNotFound{}) || errors.As(err, &domain.UserNotFound{}) {
+		notFoundResponse(c, err.Error())
+		return
+	}
+
+	if errors.As(err, &publisher.InvalidTransition{}) || errors.As(err, &publisher.DecisionRequired{}) || errors.As(err, &publisher.NotAcceptingSubmissions{}) ||
+		errors.As(err, &publisher.AlreadySubmitted{}) || errors.As(err, &publisher.InvalidSellSheet{}) || errors.As(err, &publisher.AlreadyMember{}) ||
+		errors.As(err, &publisher.LastMember{}) || errors.As(err, &publisher.OwnerRemoval{}) || errors.As(err, &publisher.NotAMember{}) ||
+		errors.As(err, &game.InvalidTransition{}) {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	serverErrorResponse(c, fallback)
+}
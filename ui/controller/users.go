@@ -1,7 +1,11 @@
 package controller
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
+	"github.com/coinflipgamesllc/api.playtest-coop.com/domain"
 	"github.com/gin-gonic/gin"
 )
 
@@ -39,3 +43,35 @@ func (t *UserController) ListUsers(c *gin.Context) {
 
 	c.JSON(200, app.ListUsersResponse{Users: users, Total: total, Limit: req.Limit, Offset: req.Offset})
 }
+
+// UserReliability returns how reliably a user shows up for the playtests they join
+// @Summary Return how reliably a user shows up for the playtests they join
+// @Produce json
+// @Param id path integer true "User ID"
+// @Success 200 {object} app.ReliabilityResponse
+// @Failure 400 {object} RequestErrorResponse
+// @Failure 404 {object} NotFoundResponse
+// @Failure 500 {object} ServerErrorResponse
+// @Tags users
+// @Router /users/:id/reliability [get]
+func (t *UserController) UserReliability(c *gin.Context) {
+	// Pull user by ID
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		requestErrorResponse(c, err.Error())
+		return
+	}
+
+	reliability, err := t.UserService.UserReliability(uint(id))
+	if err != nil {
+		if errors.As(err, &domain.UserNotFound{}) {
+			notFoundResponse(c, err.Error())
+			return
+		}
+
+		serverErrorResponse(c, "failed to fetch reliability")
+		return
+	}
+
+	c.JSON(200, app.ReliabilityResponse{Reliability: *reliability})
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
+)
+
+// PlaytestBoard keeps track of everyone watching the playtests for an event on a particular date,
+// and lets them know when any of those playtests change
+type PlaytestBoard struct {
+	watchers map[*BoardWatcher]bool
+	lock     sync.RWMutex
+}
+
+// BoardWatcher is a single client watching a board. Updates only signals that something changed,
+// and several changes in quick succession are collapsed into one, so watchers should reload the whole board.
+type BoardWatcher struct {
+	EventID uint
+	Date    time.Time // Start of the day being watched, in the event's time zone
+	Updates chan struct{}
+}
+
+// Watch starts sending updates for playtests on the day, optionally at an event. The date should be the
+// start of the day in the event's time zone, so playtests are matched to the day they fall on at the event.
+func (b *PlaytestBoard) Watch(eventID uint, date time.Time) *BoardWatcher {
+	w := &BoardWatcher{
+		EventID: eventID,
+		Date:    date,
+		Updates: make(chan struct{}, 1),
+	}
+
+	b.lock.Lock()
+	if b.watchers == nil {
+		b.watchers = map[*BoardWatcher]bool{}
+	}
+	b.watchers[w] = true
+	b.lock.Unlock()
+
+	return w
+}
+
+// Stop no longer sends updates to the watcher
+func (b *PlaytestBoard) Stop(w *BoardWatcher) {
+	b.lock.Lock()
+	delete(b.watchers, w)
+	b.lock.Unlock()
+}
+
+// playtestUpdated lets every watcher of the playtest's board know that it changed
+func (b *PlaytestBoard) playtestUpdated(msg pubsub.Message) {
+	data := msg.Data.(map[string]interface{})
+	eventID := data["eventID"].(uint)
+	dates := data["dates"].([]time.Time)
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for w := range b.watchers {
+		if !w.watches(eventID, dates) {
+			continue
+		}
+
+		// A pending update already covers this one
+		select {
+		case w.Updates <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// watches checks if a change to a playtest at the event, on any of the dates, shows up on this watcher's board
+func (w *BoardWatcher) watches(eventID uint, dates []time.Time) bool {
+	if w.EventID != 0 && w.EventID != eventID {
+		return false
+	}
+
+	y, m, d := w.Date.Date()
+	for _, date := range dates {
+		dy, dm, dd := date.In(w.Date.Location()).Date()
+		if y == dy && m == dm && d == dd {
+			return true
+		}
+	}
+
+	return false
+}
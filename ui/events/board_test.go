@@ -0,0 +1,73 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
+)
+
+func updated(eventID uint, dates ...time.Time) pubsub.Message {
+	return pubsub.Message{
+		Topic: "Playtest/Updated",
+		Data:  map[string]interface{}{"playtestID": uint(1), "eventID": eventID, "dates": dates},
+	}
+}
+
+func notified(w *BoardWatcher) bool {
+	select {
+	case <-w.Updates:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBoardWatchAndStop(t *testing.T) {
+	b := &PlaytestBoard{}
+	day := time.Date(2020, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	w := b.Watch(1, day)
+	b.playtestUpdated(updated(1, day.Add(18*time.Hour)))
+	b.playtestUpdated(updated(1, day.Add(20*time.Hour)))
+
+	if !notified(w) {
+		t.Fatal("Expected the watcher to hear about changes to its board")
+	}
+
+	if notified(w) {
+		t.Error("Expected changes in quick succession to be collapsed into one update")
+	}
+
+	b.Stop(w)
+	b.playtestUpdated(updated(1, day.Add(18*time.Hour)))
+	if notified(w) {
+		t.Error("Expected stopped watchers to stop hearing about changes")
+	}
+}
+
+func TestBoardWatches(t *testing.T) {
+	pacific := time.FixedZone("PST", -8*60*60)
+	day := time.Date(2020, 12, 16, 0, 0, 0, 0, pacific)
+
+	var tests = []struct {
+		watchedEvent uint
+		eventID      uint
+		dates        []time.Time
+		expected     bool
+	}{
+		{1, 1, []time.Time{time.Date(2020, 12, 16, 18, 0, 0, 0, pacific)}, true},
+		{0, 2, []time.Time{time.Date(2020, 12, 16, 18, 0, 0, 0, pacific)}, true},      // Watching every event
+		{1, 2, []time.Time{time.Date(2020, 12, 16, 18, 0, 0, 0, pacific)}, false},     // Another event
+		{1, 1, []time.Time{time.Date(2020, 12, 17, 2, 0, 0, 0, time.UTC)}, true},      // 6pm the 16th at the event
+		{1, 1, []time.Time{time.Date(2020, 12, 16, 6, 0, 0, 0, time.UTC)}, false},     // 10pm the 15th at the event
+		{1, 1, []time.Time{time.Date(2020, 12, 20, 18, 0, 0, 0, pacific), day}, true}, // Rescheduled away from the day
+	}
+
+	for _, tt := range tests {
+		w := &BoardWatcher{EventID: tt.watchedEvent, Date: day}
+		if actual := w.watches(tt.eventID, tt.dates); actual != tt.expected {
+			t.Errorf("Watcher of event %d: expected %v for event %d on %v", tt.watchedEvent, tt.expected, tt.eventID, tt.dates)
+		}
+	}
+}
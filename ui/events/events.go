@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/coinflipgamesllc/api.playtest-coop.com/app"
 	"github.com/coinflipgamesllc/api.playtest-coop.com/infrastructure/pubsub"
 	"go.uber.org/zap"
@@ -9,6 +11,7 @@ import (
 // EventHandler routes domain events to the proper handler
 type EventHandler struct {
 	MailService *app.MailService
+	Board       *PlaytestBoard
 	Logger      *zap.Logger
 }
 
@@ -23,6 +26,33 @@ func (h *EventHandler) ListenForEvents() {
 	userPasswordResetRequested := make(chan pubsub.Message)
 	pubsub.Instance.Subscribe("User/PasswordResetRequested", userPasswordResetRequested)
 
+	playtestPlayerPromoted := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Playtest/PlayerPromoted", playtestPlayerPromoted)
+
+	playtestCancelled := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Playtest/Cancelled", playtestCancelled)
+
+	playtestRescheduled := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Playtest/Rescheduled", playtestRescheduled)
+
+	playtestUpdated := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Playtest/Updated", playtestUpdated)
+
+	gameStatusChanged := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Game/StatusChanged", gameStatusChanged)
+
+	submissionReceived := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Submission/Received", submissionReceived)
+
+	submissionUpdated := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Submission/Updated", submissionUpdated)
+
+	gameDesignerInvited := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Game/DesignerInvited", gameDesignerInvited)
+
+	gameInvitationAnswered := make(chan pubsub.Message)
+	pubsub.Instance.Subscribe("Game/InvitationAnswered", gameInvitationAnswered)
+
 	for {
 		select {
 		case evt := <-userCreated:
@@ -31,6 +61,24 @@ func (h *EventHandler) ListenForEvents() {
 			go h.userEmailUnverified(evt)
 		case evt := <-userPasswordResetRequested:
 			go h.userPasswordResetRequested(evt)
+		case evt := <-playtestPlayerPromoted:
+			go h.playtestPlayerPromoted(evt)
+		case evt := <-playtestCancelled:
+			go h.playtestCancelled(evt)
+		case evt := <-playtestRescheduled:
+			go h.playtestRescheduled(evt)
+		case evt := <-gameStatusChanged:
+			go h.gameStatusChanged(evt)
+		case evt := <-gameDesignerInvited:
+			go h.gameDesignerInvited(evt)
+		case evt := <-gameInvitationAnswered:
+			go h.gameInvitationAnswered(evt)
+		case evt := <-submissionReceived:
+			go h.submissionReceived(evt)
+		case evt := <-submissionUpdated:
+			go h.submissionUpdated(evt)
+		case evt := <-playtestUpdated:
+			go h.Board.playtestUpdated(evt)
 		}
 	}
 }
@@ -67,3 +115,103 @@ func (h *EventHandler) userPasswordResetRequested(msg pubsub.Message) {
 		h.Logger.Error(err.Error())
 	}
 }
+
+func (h *EventHandler) playtestPlayerPromoted(msg pubsub.Message) {
+	h.Logger.Info("Received Playtest/PlayerPromoted event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	err := h.MailService.SendWaitlistPromotionEmail(data["email"].(string), data["name"].(string), data["game"].(string), data["date"].(time.Time))
+	if err != nil {
+		h.Logger.Error(err.Error())
+	}
+}
+
+func (h *EventHandler) playtestCancelled(msg pubsub.Message) {
+	h.Logger.Info("Received Playtest/Cancelled event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	for _, player := range data["players"].([]map[string]string) {
+		err := h.MailService.SendPlaytestCancelledEmail(player["email"], player["name"], data["game"].(string), data["date"].(time.Time), data["reason"].(string))
+		if err != nil {
+			h.Logger.Error(err.Error())
+		}
+	}
+}
+
+func (h *EventHandler) playtestRescheduled(msg pubsub.Message) {
+	h.Logger.Info("Received Playtest/Rescheduled event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	for _, player := range data["players"].([]map[string]string) {
+		err := h.MailService.SendPlaytestRescheduledEmail(player["email"], player["name"], data["game"].(string), data["from"].(time.Time), data["date"].(time.Time), data["reason"].(string))
+		if err != nil {
+			h.Logger.Error(err.Error())
+		}
+	}
+}
+
+func (h *EventHandler) gameStatusChanged(msg pubsub.Message) {
+	h.Logger.Info("Received Game/StatusChanged event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	for _, designer := range data["designers"].([]map[string]string) {
+		err := h.MailService.SendGameStatusChangedEmail(designer["email"], designer["name"], data["game"].(string), data["changedBy"].(string), data["from"].(string), data["to"].(string), data["publisher"].(string), data["note"].(string))
+		if err != nil {
+			h.Logger.Error(err.Error())
+		}
+	}
+}
+
+func (h *EventHandler) submissionReceived(msg pubsub.Message) {
+	h.Logger.Info("Received Submission/Received event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	for _, member := range data["recipients"].([]map[string]string) {
+		err := h.MailService.SendSubmissionReceivedEmail(member["email"], member["name"], data["game"].(string), data["publisher"].(string), data["designer"].(string), data["pitch"].(string))
+		if err != nil {
+			h.Logger.Error(err.Error())
+		}
+	}
+}
+
+func (h *EventHandler) submissionUpdated(msg pubsub.Message) {
+	h.Logger.Info("Received Submission/Updated event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	for _, recipient := range data["recipients"].([]map[string]string) {
+		err := h.MailService.SendSubmissionUpdatedEmail(recipient["email"], recipient["name"], data["game"].(string), data["publisher"].(string), data["stage"].(string), data["decision"].(string))
+		if err != nil {
+			h.Logger.Error(err.Error())
+		}
+	}
+}
+
+func (h *EventHandler) gameDesignerInvited(msg pubsub.Message) {
+	h.Logger.Info("Received Game/DesignerInvited event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	err := h.MailService.SendDesignerInvitedEmail(data["email"].(string), data["name"].(string), data["game"].(string), data["invitedBy"].(string))
+	if err != nil {
+		h.Logger.Error(err.Error())
+	}
+}
+
+func (h *EventHandler) gameInvitationAnswered(msg pubsub.Message) {
+	h.Logger.Info("Received Game/InvitationAnswered event", zap.Reflect("event", msg))
+
+	data := msg.Data.(map[string]interface{})
+
+	for _, designer := range data["designers"].([]map[string]string) {
+		err := h.MailService.SendInvitationAnsweredEmail(designer["email"], designer["name"], data["game"].(string), data["invitee"].(string), data["status"].(string))
+		if err != nil {
+			h.Logger.Error(err.Error())
+		}
+	}
+}
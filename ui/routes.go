@@ -20,6 +20,7 @@ func RegisterRoutes(container *infrastructure.Container) {
 		{
 			auth.GET("/user", container.Authenticated(), authController.GetUser)
 			auth.PUT("/user", container.Authenticated(), authController.UpdateUser)
+			auth.GET("/user/credits", container.Authenticated(), authController.CreditHistory)
 			auth.POST("/reset-password", authController.RequestResetPassword)
 			auth.GET("/reset-password/:otp", authController.ResetPassword)
 
@@ -30,12 +31,17 @@ func RegisterRoutes(container *infrastructure.Container) {
 		}
 
 		eventController := container.EventController()
+		playtestController := container.PlaytestController()
 		events := v1.Group("/events")
 		{
 			events.GET("", eventController.ListEvents)
 			events.POST("", container.Authenticated(), eventController.CreateEvent)
 			events.GET("/:id", eventController.GetEvent)
 			events.PUT("/:id", container.Authenticated(), eventController.UpdateEvent)
+			events.GET("/:id/slots", eventController.EventSlots)
+
+			events.POST("/:id/table-plan", container.Authenticated(), playtestController.ProposeTablePlan)
+			events.PUT("/:id/table-plan", container.Authenticated(), playtestController.CommitTablePlan)
 		}
 
 		fileController := container.FileController()
@@ -48,7 +54,10 @@ func RegisterRoutes(container *infrastructure.Container) {
 			files.DELETE("/:id", container.Authenticated(), fileController.DeleteFile)
 		}
 
+		publisherController := container.PublisherController()
+		designerController := container.DesignerController()
 		gameController := container.GameController()
+		analyticsController := container.AnalyticsController()
 		games := v1.Group("/games")
 		{
 			games.GET("", gameController.ListGames)
@@ -56,28 +65,91 @@ func RegisterRoutes(container *infrastructure.Container) {
 			games.GET("/:id", gameController.GetGame)
 			games.PUT("/:id", container.Authenticated(), gameController.UpdateGame)
 
+			games.GET("/:id/designers/history", container.Authenticated(), designerController.DesignerHistory)
+			games.DELETE("/:id/designers/:user", container.Authenticated(), designerController.RemoveDesigner)
+			games.PUT("/:id/owner", container.Authenticated(), designerController.TransferOwnership)
+			games.GET("/:id/invitations", container.Authenticated(), designerController.ListInvitations)
+			games.POST("/:id/invitations", container.Authenticated(), designerController.InviteDesigner)
+			games.GET("/:id/status", gameController.StatusHistory)
+			games.PUT("/:id/status", container.Authenticated(), gameController.ChangeStatus)
 			games.GET("/:id/rules", gameController.GetRules)
+			games.POST("/:id/rules", container.Authenticated(), gameController.CreateRulesSection)
+			games.PUT("/:id/rules", container.Authenticated(), gameController.ReorderRules)
+			games.GET("/:id/rules/revisions", gameController.ListRevisions)
+			games.GET("/:id/rules/diff", gameController.RulesDiff)
+			games.GET("/:id/rules/export", gameController.ExportRules)
+			games.POST("/:id/rules/import", container.Authenticated(), gameController.ImportRules)
+			games.POST("/:id/rules/revisions/:revision/restore", container.Authenticated(), gameController.RestoreRevision)
+			games.PUT("/:id/rules/:section", container.Authenticated(), gameController.UpdateRulesSection)
+			games.DELETE("/:id/rules/:section", container.Authenticated(), gameController.DeleteRulesSection)
+			games.GET("/:id/versions", gameController.ListVersions)
+			games.POST("/:id/versions", container.Authenticated(), gameController.CreateVersion)
+			games.GET("/:id/versions/:version", gameController.GetVersion)
+			games.GET("/:id/playtests", playtestController.GamePlaytests)
+			games.GET("/:id/analytics", analyticsController.GameAnalytics)
+			games.GET("/:id/submissions", container.Authenticated(), publisherController.GameSubmissions)
+		}
+
+		invitations := v1.Group("/invitations")
+		{
+			invitations.GET("", container.Authenticated(), designerController.MyInvitations)
+			invitations.PUT("/:id/accept", container.Authenticated(), designerController.AcceptInvitation)
+			invitations.PUT("/:id/decline", container.Authenticated(), designerController.DeclineInvitation)
+			invitations.PUT("/:id/cancel", container.Authenticated(), designerController.CancelInvitation)
 		}
 
-		v1.GET("/mechanics", gameController.ListAvailableMechanics)
+		mechanicController := container.MechanicController()
+		mechanics := v1.Group("/mechanics")
+		{
+			mechanics.GET("", mechanicController.ListMechanics)
+			mechanics.POST("", container.Authenticated(), mechanicController.CreateMechanic)
+			mechanics.POST("/:id/merge", container.Authenticated(), mechanicController.MergeMechanic)
+		}
 
-		playtestController := container.PlaytestController()
+		feedbackController := container.FeedbackController()
 		playtests := v1.Group("/playtests")
 		{
 			playtests.GET("", playtestController.PlaytestsOnDate)
-			playtests.POST("/register-game", playtestController.RegisterGame)
-			playtests.PUT("/:id/location", playtestController.AssignLocation)
-			playtests.PUT("/:id/player", playtestController.AddPlayer)
-			playtests.DELETE("/:id/player", playtestController.RemovePlayer)
-			playtests.PUT("/:id/start", playtestController.Start)
-			playtests.PUT("/:id/start-feedback", playtestController.StartFeedback)
-			playtests.PUT("/:id/finish", playtestController.Finish)
+			playtests.POST("/register-game", container.Authenticated(), playtestController.RegisterGame)
+			playtests.PUT("/:id/location", container.Authenticated(), playtestController.AssignLocation)
+			playtests.PUT("/:id/tts-password", container.Authenticated(), playtestController.RotateTTSPassword)
+			playtests.PUT("/:id/player", container.Authenticated(), playtestController.AddPlayer)
+			playtests.DELETE("/:id/player", container.Authenticated(), playtestController.RemovePlayer)
+			playtests.PUT("/:id/start", container.Authenticated(), playtestController.Start)
+			playtests.PUT("/:id/start-feedback", container.Authenticated(), playtestController.StartFeedback)
+			playtests.PUT("/:id/finish", container.Authenticated(), playtestController.Finish)
+			playtests.PUT("/:id/cancel", container.Authenticated(), playtestController.Cancel)
+			playtests.PUT("/:id/reschedule", container.Authenticated(), playtestController.Reschedule)
+
+			playtests.GET("/:id/feedback", container.Authenticated(), feedbackController.PlaytestFeedback)
+			playtests.PUT("/:id/feedback", container.Authenticated(), feedbackController.LeaveFeedback)
+		}
+
+		publishers := v1.Group("/publishers")
+		{
+			publishers.GET("", publisherController.ListPublishers)
+			publishers.POST("", container.Authenticated(), publisherController.CreatePublisher)
+			publishers.GET("/:id", publisherController.GetPublisher)
+			publishers.PUT("/:id", container.Authenticated(), publisherController.UpdatePublisher)
+			publishers.POST("/:id/members", container.Authenticated(), publisherController.AddMember)
+			publishers.DELETE("/:id/members/:user", container.Authenticated(), publisherController.RemoveMember)
+			publishers.GET("/:id/submissions", container.Authenticated(), publisherController.ListSubmissions)
+			publishers.POST("/:id/submissions", container.Authenticated(), publisherController.SubmitGame)
+		}
+
+		submissions := v1.Group("/submissions")
+		{
+			submissions.GET("/:id", container.Authenticated(), publisherController.GetSubmission)
+			submissions.PUT("/:id/stage", container.Authenticated(), publisherController.AdvanceSubmission)
+			submissions.POST("/:id/notes", container.Authenticated(), publisherController.AddSubmissionNote)
+			submissions.PUT("/:id/withdraw", container.Authenticated(), publisherController.WithdrawSubmission)
 		}
 
 		userController := container.UserController()
 		users := v1.Group("/users")
 		{
 			users.GET("", container.Authenticated(), userController.ListUsers)
+			users.GET("/:id/reliability", container.Authenticated(), userController.UserReliability)
 		}
 	}
 